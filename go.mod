@@ -3,7 +3,7 @@ module github.com/itsoneiota/lambda-handlers/v2
 go 1.21.3
 
 require (
-	github.com/aws/aws-lambda-go v1.34.1
+	github.com/aws/aws-lambda-go v1.47.0
 	github.com/gorilla/mux v1.8.0
 	github.com/stretchr/testify v1.7.2
 )
@@ -0,0 +1,78 @@
+package aws
+
+import (
+	"context"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+)
+
+// contextKey is an unexported type for the keys stored in a request context,
+// preventing collisions with keys defined in other packages.
+type contextKey int
+
+const (
+	requestContextKey contextKey = iota
+	requestContextV2Key
+	albRequestContextKey
+	functionURLRequestContextKey
+)
+
+// LambdaContextFromContext returns the Lambda invocation metadata (AWS request ID, function ARN...) stored in ctx.
+// Inside a http.HandlerFunc pass req.Context().
+func LambdaContextFromContext(ctx context.Context) (*lambdacontext.LambdaContext, bool) {
+	return lambdacontext.FromContext(ctx)
+}
+
+// RequestContextFromContext returns the API Gateway REST API request context (stage, authorizer claims, request ID...) stored in ctx.
+func RequestContextFromContext(ctx context.Context) (events.APIGatewayProxyRequestContext, bool) {
+	rc, ok := ctx.Value(requestContextKey).(events.APIGatewayProxyRequestContext)
+	return rc, ok
+}
+
+// RequestContextV2FromContext returns the API Gateway HTTP API request context stored in ctx.
+func RequestContextV2FromContext(ctx context.Context) (events.APIGatewayV2HTTPRequestContext, bool) {
+	rc, ok := ctx.Value(requestContextV2Key).(events.APIGatewayV2HTTPRequestContext)
+	return rc, ok
+}
+
+// ALBRequestContextFromContext returns the Application Load Balancer request context stored in ctx.
+func ALBRequestContextFromContext(ctx context.Context) (events.ALBTargetGroupRequestContext, bool) {
+	rc, ok := ctx.Value(albRequestContextKey).(events.ALBTargetGroupRequestContext)
+	return rc, ok
+}
+
+// FunctionURLRequestContextFromContext returns the Lambda Function URL request context stored in ctx.
+func FunctionURLRequestContextFromContext(ctx context.Context) (events.LambdaFunctionURLRequestContext, bool) {
+	rc, ok := ctx.Value(functionURLRequestContextKey).(events.LambdaFunctionURLRequestContext)
+	return rc, ok
+}
+
+func withRequestContext(ctx context.Context, rc events.APIGatewayProxyRequestContext) context.Context {
+	ctx = handler.WithStage(ctx, rc.Stage)
+	if rc.ResourcePath != "" {
+		ctx = handler.WithResource(ctx, rc.ResourcePath)
+	}
+
+	return context.WithValue(ctx, requestContextKey, rc)
+}
+
+func withRequestContextV2(ctx context.Context, rc events.APIGatewayV2HTTPRequestContext) context.Context {
+	ctx = handler.WithStage(ctx, rc.Stage)
+	// The route key is "METHOD /resource", or $default when no route matched.
+	if _, resource, ok := strings.Cut(rc.RouteKey, " "); ok {
+		ctx = handler.WithResource(ctx, resource)
+	}
+
+	return context.WithValue(ctx, requestContextV2Key, rc)
+}
+
+func withALBRequestContext(ctx context.Context, rc events.ALBTargetGroupRequestContext) context.Context {
+	return context.WithValue(ctx, albRequestContextKey, rc)
+}
+
+func withFunctionURLRequestContext(ctx context.Context, rc events.LambdaFunctionURLRequestContext) context.Context {
+	return context.WithValue(ctx, functionURLRequestContextKey, rc)
+}
@@ -0,0 +1,101 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/stretchr/testify/suite"
+)
+
+type ContextSuite struct {
+	suite.Suite
+	headers http.Header
+}
+
+func (s *ContextSuite) SetupTest() {
+	s.headers = http.Header{
+		"Content-Type": []string{
+			"application/json; charset=utf-8",
+		},
+	}
+}
+
+func (s *ContextSuite) TestGetHandlerPropagatesContext() {
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	ctx = lambdacontext.NewContext(ctx, &lambdacontext.LambdaContext{
+		AwsRequestID: "aws-request-id",
+	})
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		lc, ok := LambdaContextFromContext(r.Context())
+		s.True(ok)
+		s.Equal("aws-request-id", lc.AwsRequestID)
+
+		d, ok := r.Context().Deadline()
+		s.True(ok)
+		s.Equal(deadline.Add(-defaultDeadlineMargin), d)
+
+		rc, ok := RequestContextFromContext(r.Context())
+		s.True(ok)
+		s.Equal("prod", rc.Stage)
+		s.Equal("user-id", rc.Authorizer["principalId"])
+		s.Equal("prod", handler.NewContext(r).Stage())
+		s.Equal("/products/{id}", handler.ResourceFromContext(r.Context()))
+
+		_, ok = RequestContextV2FromContext(r.Context())
+		s.False(ok)
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	res, err := getHandler(h, newOptions(WithDefaultHeaders(s.headers)))(ctx, &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Stage:        "prod",
+			RequestID:    "request-id",
+			ResourcePath: "/products/{id}",
+			Authorizer: map[string]interface{}{
+				"principalId": "user-id",
+			},
+		},
+	})
+	s.NoError(err)
+	s.Equal(http.StatusOK, res.StatusCode)
+}
+
+func (s *ContextSuite) TestGetHandlerV2PropagatesContext() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		rc, ok := RequestContextV2FromContext(r.Context())
+		s.True(ok)
+		s.Equal("$default", rc.Stage)
+		s.Equal("/products/{id}", handler.ResourceFromContext(r.Context()))
+
+		w.WriteHeader(http.StatusOK)
+	}
+
+	_, err := getHandlerV2(h, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), &events.APIGatewayV2HTTPRequest{
+		RawPath: "/",
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RouteKey: "GET /products/{id}",
+			Stage:    "$default",
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: http.MethodGet,
+			},
+		},
+	})
+	s.NoError(err)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestContextSuite(t *testing.T) {
+	suite.Run(t, new(ContextSuite))
+}
@@ -0,0 +1,34 @@
+package aws
+
+import (
+	"time"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+// defaultDeadlineMargin is how long before the Lambda deadline the request context is cancelled, unless set with
+// WithDeadlineMargin. It leaves enough time to log and return a 504 response rather than have Lambda kill the
+// invocation silently.
+const defaultDeadlineMargin = 500 * time.Millisecond
+
+// errDeadlineExceeded is returned to the client when a handler is still running at the deadline.
+func errDeadlineExceeded() *serviceerror.ServiceError {
+	return serviceerror.GatewayTimeout("The request did not complete in time")
+}
+
+// finishedBy waits for done or deadline to be closed, reporting whether done was.
+// select picks at random between ready cases, so done is checked again once the deadline has passed
+// to keep the response of a handler which finished at the same moment.
+func finishedBy(done, deadline <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	case <-deadline:
+		select {
+		case <-done:
+			return true
+		default:
+			return false
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/suite"
+)
+
+type DeadlineSuite struct {
+	suite.Suite
+	headers http.Header
+	opts    *options
+}
+
+func (s *DeadlineSuite) SetupTest() {
+	s.headers = http.Header{
+		"Content-Type": []string{
+			"application/json; charset=utf-8",
+		},
+	}
+	s.opts = newOptions(
+		WithDefaultHeaders(s.headers),
+		WithDeadlineMargin(10*time.Millisecond),
+	)
+}
+
+func (s *DeadlineSuite) TestTimeoutResponse() {
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	cancelled := make(chan struct{})
+	h := func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		close(cancelled)
+	}
+
+	res, err := getHandler(h, s.opts)(ctx, &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusGatewayTimeout, res.StatusCode)
+	s.Equal("{\"error\":{\"id\":\"GATEWAY_TIMEOUT\",\"code\":\"GATEWAY_TIMEOUT\",\"message\":\"The request did not complete in time\"}}", res.Body)
+	s.Equal("application/json; charset=utf-8", res.Headers["Content-Type"])
+
+	select {
+	case <-cancelled:
+	case <-time.After(time.Second):
+		s.Fail("handler context was not cancelled")
+	}
+}
+
+func (s *DeadlineSuite) TestFinishesBeforeDeadline() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}
+
+	res, err := getHandler(h, s.opts)(ctx, &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal(`{"success":true}`, res.Body)
+}
+
+func (s *DeadlineSuite) TestFinishesAtDeadline() {
+	done := make(chan struct{})
+	deadline := make(chan struct{})
+	close(done)
+	close(deadline)
+
+	for i := 0; i < 100; i++ {
+		s.True(finishedBy(done, deadline))
+	}
+}
+
+func (s *DeadlineSuite) TestFinishedByDeadline() {
+	deadline := make(chan struct{})
+	close(deadline)
+
+	s.False(finishedBy(make(chan struct{}), deadline))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestDeadlineSuite(t *testing.T) {
+	suite.Run(t, new(DeadlineSuite))
+}
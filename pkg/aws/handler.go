@@ -1,8 +1,10 @@
 package aws
 
 import (
+	"context"
+	"log/slog"
 	"net/http"
-	"strings"
+	"time"
 
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
@@ -10,93 +12,154 @@ import (
 	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
 )
 
-type LambdaCallback = func(request *events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error)
+type LambdaCallback = func(ctx context.Context, request *events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error)
 
+// Start runs the handler behind an API Gateway REST API.
+// It is kept for compatibility, StartWithOptions accepts every available option.
 func Start(
 	h http.HandlerFunc,
 	beforeHook handler.BeforeHandlerHook,
 	afterHook handler.AfterHandlerHook,
 	defaultHeaders http.Header,
 ) {
+	StartWithOptions(
+		h,
+		WithBeforeHook(beforeHook),
+		WithAfterHook(afterHook),
+		WithDefaultHeaders(defaultHeaders),
+	)
+}
+
+// StartWithOptions runs the handler behind an API Gateway REST API.
+func StartWithOptions(h http.HandlerFunc, opts ...Option) {
 	lambda.Start(
-		getHandler(h, beforeHook, afterHook, defaultHeaders),
+		getHandler(h, newOptions(opts...)),
 	)
 }
 
-func getHandler(
-	h http.HandlerFunc,
-	beforeHook handler.BeforeHandlerHook,
-	afterHook handler.AfterHandlerHook,
-	defaultHeaders http.Header,
-) LambdaCallback {
-	return func(r *events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
-		resp := NewResponseWriter(defaultHeaders)
-		req, err := NewHttpRequest(r)
+func getHandler(h http.HandlerFunc, o *options) LambdaCallback {
+	return func(ctx context.Context, r *events.APIGatewayProxyRequest) (*events.APIGatewayProxyResponse, error) {
+		req, err := newHttpRequest(r, o.maxMultipartMemory)
 		if err != nil {
 			return nil, err
 		}
+		req = req.WithContext(withRequestContext(ctx, r.RequestContext))
 
-		vars := map[string]string{}
-		for key, value := range r.PathParameters {
-			vars[key] = value
-		}
-		req = mux.SetURLVars(req, vars)
+		resp := serve(h, o, req, r.PathParameters)
 
-		cnt := true
-		if beforeHook != nil {
-			cnt = beforeHook(resp, req)
+		return NewEvent(resp), nil
+	}
+}
+
+// serve runs the handler, wrapped in the before hook, after hook and middleware, against an already converted request.
+// When the request context carries a Lambda deadline the handler is given until the deadline margin before it,
+// after which a 504 response is returned in place of whatever the handler may still write.
+// A panic is recovered and answered with a 500 response, discarding anything the handler had written. A panic in the
+// handler is recovered inside the middleware, one in the middleware itself by serve. The response hook sees whichever
+// response is returned.
+func serve(
+	h http.HandlerFunc,
+	o *options,
+	req *http.Request,
+	pathParameters map[string]string,
+) *ResponseWriter {
+	vars := map[string]string{}
+	for key, value := range pathParameters {
+		vars[key] = value
+	}
+	req = mux.SetURLVars(req, vars)
+
+	start := time.Now()
+	resp := o.newResponseWriter(req)
+	panicked := false
+	wrapped := o.wrap(h, func(*http.Request, interface{}, []byte) {
+		panicked = true
+	})
+	run := func(req *http.Request) {
+		defer func() {
+			if recovered := recover(); recovered != nil {
+				handler.HandlePanic(req, recovered, o.panicHook)
+				panicked = true
+			}
+		}()
+
+		wrapped.ServeHTTP(resp, req)
+	}
+
+	result := func() *ResponseWriter {
+		if panicked {
+			return o.afterResponse(newServiceErrorResponseWriter(o, req, handler.PanicError()), req, start)
 		}
 
-		if cnt {
-			h(resp, req)
+		return o.afterResponse(resp, req, start)
+	}
+
+	deadline, ok := req.Context().Deadline()
+	if !ok {
+		run(req)
+		return result()
+	}
 
-			if isOkRange(resp.StatusCode) && afterHook != nil {
-				afterHook(resp)
-			}
+	ctx, cancel := context.WithDeadline(req.Context(), deadline.Add(-o.deadlineMargin))
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		run(req.WithContext(ctx))
+
+		// A handler which only returned because its context was cancelled has not completed its response.
+		if ctx.Err() == nil {
+			close(done)
 		}
+	}()
 
-		return NewEvent(resp), nil
+	if !finishedBy(done, ctx.Done()) {
+		slog.Error("handler did not finish before the lambda deadline", "method", req.Method, "path", req.URL.Path)
+
+		return o.afterResponse(newServiceErrorResponseWriter(o, req, errDeadlineExceeded()), req, start)
 	}
+
+	return result()
 }
 
+// NewEvent converts the response writer into an API Gateway proxy response.
+// Every header is returned in MultiValueHeaders so repeated headers such as Set-Cookie and Server-Timing
+// reach the client exactly as they would from a net/http server.
 func NewEvent(r *ResponseWriter) *events.APIGatewayProxyResponse {
-	headers := map[string]string{}
-	for k, v := range r.Header() {
-		if len(v) > 0 {
-			headers[k] = v[0]
-		}
-	}
+	r.Finalize()
+
+	headers, multiValueHeaders := encodeHeaders(r.Header())
 
 	return &events.APIGatewayProxyResponse{
-		StatusCode: r.StatusCode,
-		Headers:    headers,
-		Body:       r.Body,
+		StatusCode:        r.StatusCode,
+		Headers:           headers,
+		MultiValueHeaders: multiValueHeaders,
+		Body:              r.Body,
+		IsBase64Encoded:   r.IsBase64Encoded,
 	}
 }
 
-func encodeHeaders(h http.Header) map[string]string {
-	result := map[string]string{}
-
-	for hKey := range h {
-		valsUnique := unique(h.Values(hKey))
-		result[hKey] = strings.Join(valsUnique, "; ")
-	}
+// encodeHeaders splits h into the single and multi value header maps of a proxy response.
+// API Gateway merges both maps, preferring the multi value entries, so only headers with a single value are
+// duplicated into the single value map. Set-Cookie is never folded into the single value map as cookies cannot
+// be joined into one header.
+func encodeHeaders(h http.Header) (map[string]string, map[string][]string) {
+	headers := map[string]string{}
+	multiValueHeaders := map[string][]string{}
 
-	return result
-}
+	for k, v := range h {
+		if len(v) == 0 {
+			continue
+		}
 
-func unique(slice []string) []string {
-	encountered := map[string]bool{}
-	result := []string{}
+		multiValueHeaders[k] = append([]string{}, v...)
 
-	for _, value := range slice {
-		if !encountered[value] {
-			encountered[value] = true
-			result = append(result, value)
+		if len(v) == 1 && http.CanonicalHeaderKey(k) != "Set-Cookie" {
+			headers[k] = v[0]
 		}
 	}
 
-	return result
+	return headers, multiValueHeaders
 }
 
 func isOkRange(statusCode int) bool {
@@ -1,9 +1,17 @@
 package aws
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -26,12 +34,183 @@ func (s *HandlerSuite) SetupTest() {
 }
 
 func (s *HandlerSuite) TestEncodeHeaders() {
-	expect := map[string]string{
+	expectHeaders := map[string]string{
 		"Content-Type": "application/json; charset=utf-8",
-		"Test":         "foo; bar",
+	}
+	expectMultiValueHeaders := map[string][]string{
+		"Content-Type": {"application/json; charset=utf-8"},
+		"Test":         {"foo", "bar", "bar"},
+	}
+
+	headers, multiValueHeaders := encodeHeaders(s.headers)
+	s.Equal(expectHeaders, headers)
+	s.Equal(expectMultiValueHeaders, multiValueHeaders)
+}
+
+func (s *HandlerSuite) TestEncodeHeadersCookie() {
+	s.headers.Add("Set-Cookie", "loggedIn=True; path=/; secure")
+
+	headers, multiValueHeaders := encodeHeaders(s.headers)
+	s.NotContains(headers, "Set-Cookie")
+	s.Equal([]string{"loggedIn=True; path=/; secure"}, multiValueHeaders["Set-Cookie"])
+}
+
+func (s *HandlerSuite) TestNewEventMatchesLocalResponse() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "loggedIn=True; path=/; secure")
+		w.Header().Add("Set-Cookie", "theme=dark; path=/")
+		w.Header().Add("Server-Timing", "cdn-cache; desc=HIT")
+		w.Header().Add("Server-Timing", "edge; dur=1")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}
+
+	local := httptest.NewRecorder()
+	local.Header().Set("Content-Type", "application/json; charset=utf-8")
+	h(local, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	defaultHeaders := http.Header{"Content-Type": {"application/json; charset=utf-8"}}
+	res, err := getHandler(h, newOptions(WithDefaultHeaders(defaultHeaders)))(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(map[string][]string(local.Header()), res.MultiValueHeaders)
+	s.Equal(local.Body.String(), res.Body)
+}
+
+func (s *HandlerSuite) TestPanicRecovery() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+		panic("boom")
+	}
+
+	res, err := getHandler(h, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusInternalServerError, res.StatusCode)
+	s.Equal(`{"error":{"id":"INTERNAL_SERVER_ERROR","code":"INTERNAL_SERVER_ERROR","message":"An internal error occurred"}}`, res.Body)
+}
+
+func (s *HandlerSuite) TestProblemFormatFromAccept() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("boom")
+		}
+
+		handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.NotFound("Product not found"))
+	}
+
+	for path, expected := range map[string]string{
+		"/products/ABC123": `{"type":"about:blank","title":"Not Found","status":404,"detail":"Product not found","instance":"/products/ABC123","code":"NOT_FOUND"}`,
+		"/panic":           `{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"An internal error occurred","instance":"/panic","code":"INTERNAL_SERVER_ERROR"}`,
+	} {
+		res, err := getHandler(h, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), &events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       path,
+			Headers:    map[string]string{"Accept": "application/problem+json"},
+		})
+		s.NoError(err)
+
+		s.Equal("application/problem+json", res.Headers["Content-Type"])
+		s.JSONEq(expected, res.Body)
+	}
+}
+
+func (s *HandlerSuite) TestWithErrorFormat() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.NotFound("Product not found"))
+	}
+
+	for accept, expected := range map[string]string{
+		"":                                  `{"type":"about:blank","title":"Not Found","status":404,"detail":"Product not found","instance":"/products/ABC123","code":"NOT_FOUND"}`,
+		"application/problem+json;q=0":      `{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"Product not found"}}`,
+		"application/json, application/xml": `{"type":"about:blank","title":"Not Found","status":404,"detail":"Product not found","instance":"/products/ABC123","code":"NOT_FOUND"}`,
+	} {
+		res, err := getHandler(h, newOptions(WithErrorFormat(handler.ProblemFormat)))(context.Background(), &events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodGet,
+			Path:       "/products/ABC123",
+			Headers:    map[string]string{"Accept": accept},
+		})
+		s.NoError(err)
+
+		s.Equal(http.StatusNotFound, res.StatusCode)
+		s.JSONEq(expected, res.Body, accept)
+	}
+}
+
+func (s *HandlerSuite) TestPanicRecoveryWithDeadline() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
 	}
 
-	s.Equal(expect, encodeHeaders(s.headers))
+	res, err := getHandler(h, newOptions(WithDefaultHeaders(s.headers)))(ctx, &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusInternalServerError, res.StatusCode)
+}
+
+func (s *HandlerSuite) TestHeadersDoNotLeakBetweenInvocations() {
+	callback := getHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("X-Request", r.URL.Query().Get("id"))
+		w.Header().Add("Set-Cookie", "id="+r.URL.Query().Get("id"))
+		w.WriteHeader(http.StatusOK)
+	}, newOptions(WithDefaultHeaders(s.headers)))
+
+	first, err := callback(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodGet,
+		Path:                  "/",
+		QueryStringParameters: map[string]string{"id": "first"},
+	})
+	s.NoError(err)
+
+	second, err := callback(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod:            http.MethodGet,
+		Path:                  "/",
+		QueryStringParameters: map[string]string{"id": "second"},
+	})
+	s.NoError(err)
+
+	s.Equal([]string{"first"}, first.MultiValueHeaders["X-Request"])
+	s.Equal([]string{"second"}, second.MultiValueHeaders["X-Request"])
+	s.Equal([]string{"id=second"}, second.MultiValueHeaders["Set-Cookie"])
+	s.Equal([]string{"foo", "bar", "bar"}, second.MultiValueHeaders["Test"])
+	s.NotContains(s.headers, "X-Request")
+}
+
+func (s *HandlerSuite) TestConcurrentInvocations() {
+	callback := getHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request", r.URL.Query().Get("id"))
+		w.WriteHeader(http.StatusOK)
+	}, newOptions(WithDefaultHeaders(s.headers)))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+
+			res, err := callback(context.Background(), &events.APIGatewayProxyRequest{
+				HTTPMethod:            http.MethodGet,
+				Path:                  "/",
+				QueryStringParameters: map[string]string{"id": id},
+			})
+			s.NoError(err)
+			s.Equal(id, res.Headers["X-Request"])
+		}(strconv.Itoa(i))
+	}
+	wg.Wait()
 }
 
 // In order for 'go test' to run this suite, we need to create
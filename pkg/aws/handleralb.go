@@ -0,0 +1,68 @@
+package aws
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type LambdaCallbackALB = func(ctx context.Context, request *events.ALBTargetGroupRequest) (*events.ALBTargetGroupResponse, error)
+
+// StartALB runs the handler as an Application Load Balancer target.
+func StartALB(h http.HandlerFunc, opts ...Option) {
+	lambda.Start(
+		getHandlerALB(h, newOptions(opts...)),
+	)
+}
+
+func getHandlerALB(h http.HandlerFunc, o *options) LambdaCallbackALB {
+	return func(ctx context.Context, r *events.ALBTargetGroupRequest) (*events.ALBTargetGroupResponse, error) {
+		req, err := newHttpRequestALB(r, o.maxMultipartMemory)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(withALBRequestContext(ctx, r.RequestContext))
+
+		resp := serve(h, o, req, nil)
+
+		return NewEventALB(resp, isMultiValueALBRequest(r)), nil
+	}
+}
+
+// NewEventALB converts the response writer into an Application Load Balancer target group response.
+// When the target group has multi value headers enabled the load balancer ignores the single value
+// headers field, so multiValue selects which of the two shapes is populated.
+func NewEventALB(r *ResponseWriter, multiValue bool) *events.ALBTargetGroupResponse {
+	r.Finalize()
+
+	res := &events.ALBTargetGroupResponse{
+		StatusCode:        r.StatusCode,
+		StatusDescription: fmt.Sprintf("%d %s", r.StatusCode, http.StatusText(r.StatusCode)),
+		Body:              r.Body,
+		IsBase64Encoded:   r.IsBase64Encoded,
+	}
+
+	if multiValue {
+		_, res.MultiValueHeaders = encodeHeaders(r.Header())
+
+		return res
+	}
+
+	res.Headers = map[string]string{}
+	for k, v := range r.Header() {
+		if len(v) > 0 {
+			res.Headers[k] = v[0]
+		}
+	}
+
+	return res
+}
+
+// isMultiValueALBRequest reports whether the target group has multi value headers enabled,
+// in which case the load balancer only sends the multi value fields.
+func isMultiValueALBRequest(r *events.ALBTargetGroupRequest) bool {
+	return r.MultiValueHeaders != nil || r.MultiValueQueryStringParameters != nil
+}
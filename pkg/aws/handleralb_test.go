@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandlerALBSuite struct {
+	suite.Suite
+	headers http.Header
+	handler http.HandlerFunc
+}
+
+func (s *HandlerALBSuite) SetupTest() {
+	s.headers = http.Header{
+		"Content-Type": []string{
+			"application/json; charset=utf-8",
+		},
+	}
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "loggedIn=True; path=/; secure")
+		w.Header().Add("Set-Cookie", "theme=dark; path=/")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}
+}
+
+func (s *HandlerALBSuite) TestGetHandlerALB() {
+	res, err := getHandlerALB(s.handler, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), &events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+		Headers: map[string]string{
+			"host": "example.com",
+		},
+	})
+	s.NoError(err)
+
+	expect := &events.ALBTargetGroupResponse{
+		StatusCode:        http.StatusOK,
+		StatusDescription: "200 OK",
+		Headers: map[string]string{
+			"Content-Type": "application/json; charset=utf-8",
+			"Set-Cookie":   "loggedIn=True; path=/; secure",
+		},
+		Body: `{"success":true}`,
+	}
+
+	s.Equal(expect, res)
+}
+
+func (s *HandlerALBSuite) TestGetHandlerALBMultiValue() {
+	res, err := getHandlerALB(s.handler, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), &events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+		MultiValueHeaders: map[string][]string{
+			"host": {"example.com"},
+		},
+	})
+	s.NoError(err)
+
+	expect := &events.ALBTargetGroupResponse{
+		StatusCode:        http.StatusOK,
+		StatusDescription: "200 OK",
+		MultiValueHeaders: map[string][]string{
+			"Content-Type": {"application/json; charset=utf-8"},
+			"Set-Cookie": {
+				"loggedIn=True; path=/; secure",
+				"theme=dark; path=/",
+			},
+		},
+		Body: `{"success":true}`,
+	}
+
+	s.Equal(expect, res)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestHandlerALBSuite(t *testing.T) {
+	suite.Run(t, new(HandlerALBSuite))
+}
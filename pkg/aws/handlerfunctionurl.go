@@ -0,0 +1,45 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type LambdaCallbackFunctionURL = func(ctx context.Context, request *events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLResponse, error)
+
+// StartFunctionURL runs the handler behind a Lambda Function URL.
+func StartFunctionURL(h http.HandlerFunc, opts ...Option) {
+	lambda.Start(
+		getHandlerFunctionURL(h, newOptions(opts...)),
+	)
+}
+
+func getHandlerFunctionURL(h http.HandlerFunc, o *options) LambdaCallbackFunctionURL {
+	return func(ctx context.Context, r *events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLResponse, error) {
+		req, err := newHttpRequestFunctionURL(r, o.maxMultipartMemory)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(withFunctionURLRequestContext(ctx, r.RequestContext))
+
+		resp := serve(h, o, req, nil)
+
+		return NewEventFunctionURL(resp), nil
+	}
+}
+
+// NewEventFunctionURL converts the response writer into a Lambda Function URL response.
+func NewEventFunctionURL(r *ResponseWriter) *events.LambdaFunctionURLResponse {
+	e := NewEventV2(r)
+
+	return &events.LambdaFunctionURLResponse{
+		StatusCode:      e.StatusCode,
+		Headers:         e.Headers,
+		Body:            e.Body,
+		IsBase64Encoded: e.IsBase64Encoded,
+		Cookies:         e.Cookies,
+	}
+}
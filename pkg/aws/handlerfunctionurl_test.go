@@ -0,0 +1,86 @@
+package aws
+
+import (
+	"context"
+	"encoding/base64"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandlerFunctionURLSuite struct {
+	suite.Suite
+	headers http.Header
+	req     *events.LambdaFunctionURLRequest
+}
+
+func (s *HandlerFunctionURLSuite) SetupTest() {
+	s.headers = http.Header{
+		"Content-Type": []string{
+			"application/json; charset=utf-8",
+		},
+	}
+	s.req = &events.LambdaFunctionURLRequest{
+		Version:        "2.0",
+		RawPath:        "/export",
+		RawQueryString: "format=csv",
+		Cookies:        []string{"session=abc"},
+		Headers: map[string]string{
+			"host": "url-id.lambda-url.eu-west-1.on.aws",
+		},
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			DomainName: "url-id.lambda-url.eu-west-1.on.aws",
+			HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{
+				Method:   http.MethodPost,
+				Path:     "/export",
+				SourceIP: "127.0.0.1",
+			},
+		},
+		Body:            base64.StdEncoding.EncodeToString([]byte(`{"name":"Example Product"}`)),
+		IsBase64Encoded: true,
+	}
+}
+
+func (s *HandlerFunctionURLSuite) TestGetHandlerFunctionURL() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		s.Equal(http.MethodPost, r.Method)
+		s.Equal("/export", r.URL.Path)
+		s.Equal("csv", r.URL.Query().Get("format"))
+		s.Equal("127.0.0.1", r.RemoteAddr)
+
+		cookie, err := r.Cookie("session")
+		s.NoError(err)
+		s.Equal("abc", cookie.Value)
+
+		b, err := io.ReadAll(r.Body)
+		s.NoError(err)
+		s.Equal(`{"name":"Example Product"}`, string(b))
+
+		w.Header().Add("Set-Cookie", "theme=dark; path=/")
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"success":true}`))
+	}
+
+	res, err := getHandlerFunctionURL(h, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), s.req)
+	s.NoError(err)
+
+	expect := &events.LambdaFunctionURLResponse{
+		StatusCode: http.StatusCreated,
+		Headers: map[string]string{
+			"Content-Type": "application/json; charset=utf-8",
+		},
+		Body:    `{"success":true}`,
+		Cookies: []string{"theme=dark; path=/"},
+	}
+
+	s.Equal(expect, res)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestHandlerFunctionURLSuite(t *testing.T) {
+	suite.Run(t, new(HandlerFunctionURLSuite))
+}
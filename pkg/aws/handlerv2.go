@@ -0,0 +1,69 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+)
+
+type LambdaCallbackV2 = func(ctx context.Context, request *events.APIGatewayV2HTTPRequest) (*events.APIGatewayV2HTTPResponse, error)
+
+// StartV2 runs the handler behind an API Gateway HTTP API using payload format 2.0.
+func StartV2(h http.HandlerFunc, opts ...Option) {
+	lambda.Start(
+		getHandlerV2(h, newOptions(opts...)),
+	)
+}
+
+func getHandlerV2(h http.HandlerFunc, o *options) LambdaCallbackV2 {
+	return func(ctx context.Context, r *events.APIGatewayV2HTTPRequest) (*events.APIGatewayV2HTTPResponse, error) {
+		req, err := newHttpRequestV2(r, o.maxMultipartMemory)
+		if err != nil {
+			return nil, err
+		}
+		req = req.WithContext(withRequestContextV2(ctx, r.RequestContext))
+
+		resp := serve(h, o, req, r.PathParameters)
+
+		return NewEventV2(resp), nil
+	}
+}
+
+// NewEventV2 converts the response writer into an API Gateway HTTP API (payload format 2.0) response.
+// Set-Cookie headers are returned in the cookies field, all other multi-value headers are comma separated.
+func NewEventV2(r *ResponseWriter) *events.APIGatewayV2HTTPResponse {
+	r.Finalize()
+
+	headers, cookies := encodeHeadersV2(r.Header())
+
+	return &events.APIGatewayV2HTTPResponse{
+		StatusCode:      r.StatusCode,
+		Headers:         headers,
+		Body:            r.Body,
+		IsBase64Encoded: r.IsBase64Encoded,
+		Cookies:         cookies,
+	}
+}
+
+// encodeHeadersV2 splits h into the comma separated headers and the cookies of a payload format 2.0 response.
+func encodeHeadersV2(h http.Header) (map[string]string, []string) {
+	headers := map[string]string{}
+	var cookies []string
+	for k, v := range h {
+		if len(v) == 0 {
+			continue
+		}
+
+		if http.CanonicalHeaderKey(k) == "Set-Cookie" {
+			cookies = append(cookies, v...)
+			continue
+		}
+
+		headers[k] = strings.Join(v, ",")
+	}
+
+	return headers, cookies
+}
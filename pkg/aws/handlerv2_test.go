@@ -0,0 +1,84 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandlerV2Suite struct {
+	suite.Suite
+	headers http.Header
+}
+
+func (s *HandlerV2Suite) SetupTest() {
+	s.headers = http.Header{
+		"Content-Type": []string{
+			"application/json; charset=utf-8",
+		},
+	}
+}
+
+func (s *HandlerV2Suite) TestNewEventV2() {
+	r := NewResponseWriter(s.headers)
+	r.Header().Add("Server-Timing", "cdn-cache; desc=HIT")
+	r.Header().Add("Server-Timing", "edge; dur=1")
+	r.Header().Add("Set-Cookie", "loggedIn=True; path=/; secure")
+	r.Header().Add("Set-Cookie", "theme=dark; path=/")
+	r.WriteHeader(http.StatusCreated)
+	r.Write([]byte(`{"success":true}`))
+
+	expect := &events.APIGatewayV2HTTPResponse{
+		StatusCode: http.StatusCreated,
+		Headers: map[string]string{
+			"Content-Type":  "application/json; charset=utf-8",
+			"Server-Timing": "cdn-cache; desc=HIT,edge; dur=1",
+		},
+		Body: `{"success":true}`,
+		Cookies: []string{
+			"loggedIn=True; path=/; secure",
+			"theme=dark; path=/",
+		},
+	}
+
+	s.Equal(expect, NewEventV2(r))
+}
+
+func (s *HandlerV2Suite) TestGetHandlerV2() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		cookie, err := r.Cookie("session")
+		s.NoError(err)
+		s.Equal("abc", cookie.Value)
+		s.Equal("ABC123", mux.Vars(r)["id"])
+
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}
+
+	res, err := getHandlerV2(h, newOptions(WithDefaultHeaders(s.headers)))(context.Background(), &events.APIGatewayV2HTTPRequest{
+		RawPath: "/products/ABC123",
+		Cookies: []string{"session=abc"},
+		PathParameters: map[string]string{
+			"id": "ABC123",
+		},
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method: http.MethodGet,
+			},
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal(`{"success":true}`, res.Body)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestHandlerV2Suite(t *testing.T) {
+	suite.Run(t, new(HandlerV2Suite))
+}
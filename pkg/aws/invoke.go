@@ -0,0 +1,156 @@
+package aws
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Invoke runs a single APIGatewayProxyRequest through the handler exactly as Start would and returns the response.
+func Invoke(
+	ctx context.Context,
+	h http.HandlerFunc,
+	event *events.APIGatewayProxyRequest,
+	opts ...Option,
+) (*events.APIGatewayProxyResponse, error) {
+	return getHandler(h, newOptions(opts...))(ctx, event)
+}
+
+// InvokeMain is a helper for a tiny main which replays an API Gateway event, e.g. one copied from CloudWatch, against the handler.
+// It reads the command line arguments, runs the event and prints the response, exiting with a non-zero status on failure.
+func InvokeMain(h http.HandlerFunc, opts ...Option) {
+	if err := InvokeCLI(os.Args[1:], os.Stdin, os.Stdout, os.Stderr, h, opts...); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// InvokeCLI reads an APIGatewayProxyRequest JSON event, runs it through the handler and writes the APIGatewayProxyResponse JSON to out.
+// Usage and argument errors are written to errOut, keeping out safe to pipe.
+//
+// Supported arguments:
+//
+//	-event      path to the event file, "-" (the default) reads from in
+//	-header     "Name: value" header to set on the event, replacing any header of the same name, may be repeated
+//	-body       replacement request body
+//	-path-param "name=value" path parameter to set on the event, may be repeated
+func InvokeCLI(args []string, in io.Reader, out, errOut io.Writer, h http.HandlerFunc, opts ...Option) error {
+	var (
+		eventPath  string
+		body       string
+		headers    keyValueFlag
+		pathParams keyValueFlag
+	)
+
+	fs := flag.NewFlagSet("invoke", flag.ContinueOnError)
+	fs.SetOutput(errOut)
+	fs.StringVar(&eventPath, "event", "-", "path to the API Gateway event JSON, - reads from stdin")
+	fs.StringVar(&body, "body", "", "replacement request body")
+	headers.sep = ":"
+	fs.Var(&headers, "header", "\"Name: value\" header to set on the event, may be repeated")
+	pathParams.sep = "="
+	fs.Var(&pathParams, "path-param", "\"name=value\" path parameter to set on the event, may be repeated")
+
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if eventPath != "-" {
+		f, err := os.Open(eventPath)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+
+		in = f
+	}
+
+	event := &events.APIGatewayProxyRequest{}
+	if err := json.NewDecoder(in).Decode(event); err != nil {
+		return fmt.Errorf("decoding event: %w", err)
+	}
+
+	for _, kv := range headers.values {
+		if event.Headers == nil {
+			event.Headers = map[string]string{}
+		}
+		setHeader(event.Headers, kv[0], kv[1])
+
+		if event.MultiValueHeaders != nil {
+			setHeader(event.MultiValueHeaders, kv[0], []string{kv[1]})
+		}
+	}
+
+	for _, kv := range pathParams.values {
+		if event.PathParameters == nil {
+			event.PathParameters = map[string]string{}
+		}
+		event.PathParameters[kv[0]] = kv[1]
+	}
+
+	bodySet := false
+	fs.Visit(func(f *flag.Flag) {
+		if f.Name == "body" {
+			bodySet = true
+		}
+	})
+
+	if bodySet {
+		event.Body = body
+		event.IsBase64Encoded = false
+	}
+
+	res, err := Invoke(context.Background(), h, event, opts...)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(out)
+	enc.SetIndent("", "  ")
+
+	return enc.Encode(res)
+}
+
+// setHeader sets the header name in headers to value, replacing any existing entry whatever the casing of its key.
+func setHeader[V any](headers map[string]V, name string, value V) {
+	for key := range headers {
+		if strings.EqualFold(key, name) {
+			delete(headers, key)
+		}
+	}
+
+	headers[name] = value
+}
+
+// keyValueFlag collects repeated "key<sep>value" command line arguments.
+type keyValueFlag struct {
+	sep    string
+	values [][2]string
+}
+
+func (f *keyValueFlag) String() string {
+	pairs := make([]string, 0, len(f.values))
+	for _, kv := range f.values {
+		pairs = append(pairs, kv[0]+f.sep+kv[1])
+	}
+
+	return strings.Join(pairs, ", ")
+}
+
+func (f *keyValueFlag) Set(s string) error {
+	k, v, ok := strings.Cut(s, f.sep)
+	if !ok {
+		return fmt.Errorf("expected key%svalue, got %q", f.sep, s)
+	}
+
+	f.values = append(f.values, [2]string{strings.TrimSpace(k), strings.TrimSpace(v)})
+
+	return nil
+}
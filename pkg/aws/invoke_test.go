@@ -0,0 +1,121 @@
+package aws
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type InvokeSuite struct {
+	suite.Suite
+	event   string
+	handler http.HandlerFunc
+}
+
+func (s *InvokeSuite) SetupTest() {
+	s.event = `{
+		"resource": "/products/{id}",
+		"path": "/products/ABC123",
+		"httpMethod": "PUT",
+		"headers": {"Host": "example.com", "Authorization": "Bearer old"},
+		"multiValueHeaders": {"Host": ["example.com"], "Authorization": ["Bearer old"]},
+		"pathParameters": {"id": "ABC123"},
+		"body": "{\"name\": \"Example Product\"}",
+		"isBase64Encoded": false
+	}`
+	s.handler = func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string]string{
+			"id":            mux.Vars(r)["id"],
+			"authorization": r.Header.Get("Authorization"),
+			"body":          string(b),
+		})
+	}
+}
+
+func (s *InvokeSuite) decode(out *bytes.Buffer) map[string]string {
+	res := &events.APIGatewayProxyResponse{}
+	s.NoError(json.Unmarshal(out.Bytes(), res))
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	body := map[string]string{}
+	s.NoError(json.Unmarshal([]byte(res.Body), &body))
+
+	return body
+}
+
+func (s *InvokeSuite) TestInvokeCLIStdin() {
+	var out bytes.Buffer
+	err := InvokeCLI(nil, strings.NewReader(s.event), &out, io.Discard, s.handler)
+	s.NoError(err)
+
+	body := s.decode(&out)
+	s.Equal("ABC123", body["id"])
+	s.Equal("Bearer old", body["authorization"])
+	s.Equal(`{"name": "Example Product"}`, body["body"])
+}
+
+func (s *InvokeSuite) TestInvokeCLIOverrides() {
+	path := filepath.Join(s.T().TempDir(), "event.json")
+	s.NoError(os.WriteFile(path, []byte(s.event), 0o600))
+
+	var out bytes.Buffer
+	err := InvokeCLI([]string{
+		"-event", path,
+		"-header", "Authorization: Bearer new",
+		"-path-param", "id=XYZ789",
+		"-body", "{}",
+	}, strings.NewReader(""), &out, io.Discard, s.handler)
+	s.NoError(err)
+
+	body := s.decode(&out)
+	s.Equal("XYZ789", body["id"])
+	s.Equal("Bearer new", body["authorization"])
+	s.Equal("{}", body["body"])
+}
+
+func (s *InvokeSuite) TestInvokeCLIHeaderCasing() {
+	var out bytes.Buffer
+	err := InvokeCLI([]string{
+		"-header", "authorization: Bearer new",
+	}, strings.NewReader(s.event), &out, io.Discard, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(map[string][]string{
+			"authorization": r.Header.Values("Authorization"),
+		})
+	})
+	s.NoError(err)
+
+	res := &events.APIGatewayProxyResponse{}
+	s.NoError(json.Unmarshal(out.Bytes(), res))
+	s.JSONEq(`{"authorization":["Bearer new"]}`, res.Body)
+}
+
+func (s *InvokeSuite) TestInvokeCLIInvalidFlag() {
+	for _, args := range [][]string{{"-header", "missing-separator"}, {"-unknown"}, {"-h"}} {
+		var out, errOut bytes.Buffer
+		err := InvokeCLI(args, strings.NewReader(s.event), &out, &errOut, s.handler)
+		s.Error(err)
+
+		s.Empty(out.String(), args)
+		s.Contains(errOut.String(), "Usage of invoke", args)
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestInvokeSuite(t *testing.T) {
+	suite.Run(t, new(InvokeSuite))
+}
@@ -0,0 +1,166 @@
+package aws
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+)
+
+// LocalStage is the stage name given to events built by the local emulator.
+const LocalStage = "local"
+
+// StartLocal serves the handler on addr through the same API Gateway event conversion used in Lambda.
+// resource is the API Gateway resource template the handler is deployed under, e.g. /products/{id}.
+func StartLocal(addr, resource string, h http.HandlerFunc, opts ...Option) error {
+	return http.ListenAndServe(addr, NewLocalHandler(resource, h, opts...))
+}
+
+// NewLocalHandler returns a http.Handler which emulates API Gateway in front of the handler.
+// Each request is converted into an APIGatewayProxyRequest, with path parameters taken from the resource template,
+// passed through the same LambdaCallback used by Start and the resulting APIGatewayProxyResponse written back.
+// This exercises the exact Lambda code path (header, base64 and multipart conversion) locally.
+func NewLocalHandler(resource string, h http.HandlerFunc, opts ...Option) http.Handler {
+	o := newOptions(opts...)
+	callback := getHandler(h, o)
+
+	r := mux.NewRouter()
+	r.Path(handler.MuxTemplate(resource)).HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		event, err := NewEventFromHttpRequest(req, resource, mux.Vars(req), o.binaryMediaTypes)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		res, err := callback(req.Context(), event)
+		if err != nil {
+			// Lambda reports a failed invocation to API Gateway, which answers with a 502.
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+
+		if err := WriteEvent(w, res); err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+		}
+	})
+
+	return r
+}
+
+// NewEventFromHttpRequest builds the APIGatewayProxyRequest API Gateway would send to Lambda for req.
+// Bodies which are not valid UTF-8 or match binaryMediaTypes are base64 encoded.
+func NewEventFromHttpRequest(
+	req *http.Request,
+	resource string,
+	pathParameters map[string]string,
+	binaryMediaTypes []string,
+) (*events.APIGatewayProxyRequest, error) {
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	header := req.Header.Clone()
+	header.Set("Host", req.Host)
+	if header.Get("X-Forwarded-Proto") == "" {
+		scheme := "http"
+		if req.TLS != nil {
+			scheme = "https"
+		}
+		header.Set("X-Forwarded-Proto", scheme)
+	}
+
+	headers := map[string]string{}
+	multiValueHeaders := map[string][]string{}
+	for k, v := range header {
+		headers[k] = v[len(v)-1]
+		multiValueHeaders[k] = v
+	}
+
+	queryStringParameters := map[string]string{}
+	multiValueQueryStringParameters := map[string][]string{}
+	for k, v := range req.URL.Query() {
+		queryStringParameters[k] = v[len(v)-1]
+		multiValueQueryStringParameters[k] = v
+	}
+
+	sourceIP := req.RemoteAddr
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		sourceIP = host
+	}
+
+	now := time.Now()
+	event := &events.APIGatewayProxyRequest{
+		Resource:                        resource,
+		Path:                            req.URL.Path,
+		HTTPMethod:                      req.Method,
+		Headers:                         headers,
+		MultiValueHeaders:               multiValueHeaders,
+		QueryStringParameters:           queryStringParameters,
+		MultiValueQueryStringParameters: multiValueQueryStringParameters,
+		PathParameters:                  pathParameters,
+		RequestContext: events.APIGatewayProxyRequestContext{
+			Stage:     LocalStage,
+			RequestID: newLocalRequestID(),
+			Protocol:  req.Proto,
+			Identity: events.APIGatewayRequestIdentity{
+				SourceIP:  sourceIP,
+				UserAgent: req.UserAgent(),
+			},
+			ResourcePath:     resource,
+			Path:             req.URL.Path,
+			HTTPMethod:       req.Method,
+			RequestTime:      now.UTC().Format("02/Jan/2006:15:04:05 -0700"),
+			RequestTimeEpoch: now.UnixMilli(),
+		},
+	}
+
+	if !utf8.Valid(body) || isBinaryMediaType(req.Header.Get("Content-Type"), binaryMediaTypes) {
+		event.Body = base64.StdEncoding.EncodeToString(body)
+		event.IsBase64Encoded = true
+	} else {
+		event.Body = string(body)
+	}
+
+	return event, nil
+}
+
+// WriteEvent writes an APIGatewayProxyResponse to w as API Gateway would return it to the client.
+func WriteEvent(w http.ResponseWriter, e *events.APIGatewayProxyResponse) error {
+	for k, v := range e.Headers {
+		if _, ok := e.MultiValueHeaders[k]; !ok {
+			w.Header().Set(k, v)
+		}
+	}
+
+	for k, vals := range e.MultiValueHeaders {
+		for _, v := range vals {
+			w.Header().Add(k, v)
+		}
+	}
+
+	body, err := decodeBody(e.Body, e.IsBase64Encoded)
+	if err != nil {
+		return err
+	}
+
+	w.WriteHeader(e.StatusCode)
+	_, err = w.Write(body)
+
+	return err
+}
+
+func newLocalRequestID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,104 @@
+package aws
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalSuite struct {
+	suite.Suite
+	headers http.Header
+}
+
+func (s *LocalSuite) SetupTest() {
+	s.headers = http.Header{
+		"Content-Type": []string{
+			"application/json; charset=utf-8",
+		},
+	}
+}
+
+func (s *LocalSuite) TestNewLocalHandler() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		s.Equal("ABC123", mux.Vars(r)["id"])
+		s.Equal([]string{"attributes", "tabs"}, r.URL.Query()["extend"])
+		s.Equal([]string{"value1", "value2"}, r.Header.Values("X-Custom-Header"))
+
+		rc, ok := RequestContextFromContext(r.Context())
+		s.True(ok)
+		s.Equal(LocalStage, rc.Stage)
+		s.Equal("/products/{id}", rc.ResourcePath)
+
+		w.Header().Add("Set-Cookie", "a=1")
+		w.Header().Add("Set-Cookie", "b=2")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"success":true}`))
+	}
+
+	server := httptest.NewServer(NewLocalHandler("/products/{id}", h, WithDefaultHeaders(s.headers)))
+	defer server.Close()
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/products/ABC123?extend=attributes&extend=tabs", nil)
+	s.NoError(err)
+	req.Header.Add("X-Custom-Header", "value1")
+	req.Header.Add("X-Custom-Header", "value2")
+
+	res, err := http.DefaultClient.Do(req)
+	s.NoError(err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal(`{"success":true}`, string(body))
+	s.Equal("application/json; charset=utf-8", res.Header.Get("Content-Type"))
+	s.Equal([]string{"a=1", "b=2"}, res.Header.Values("Set-Cookie"))
+}
+
+func (s *LocalSuite) TestNewLocalHandlerBinary() {
+	payload := []byte{0xff, 0x00, 0xfe}
+	h := func(w http.ResponseWriter, r *http.Request) {
+		file, _, err := r.FormFile("file")
+		s.NoError(err)
+
+		b, err := io.ReadAll(file)
+		s.NoError(err)
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.Write(b)
+	}
+
+	server := httptest.NewServer(NewLocalHandler("/upload/{path+}", h))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	fw, err := mw.CreateFormFile("file", "file.bin")
+	s.NoError(err)
+	fw.Write(payload)
+	mw.Close()
+
+	res, err := http.Post(server.URL+"/upload/a/b/file.bin", mw.FormDataContentType(), &buf)
+	s.NoError(err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal(payload, body)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestLocalSuite(t *testing.T) {
+	suite.Run(t, new(LocalSuite))
+}
@@ -0,0 +1,164 @@
+package aws
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+)
+
+// DefaultMaxMultipartMemory is the number of bytes of a multipart/form-data body held in memory, the remainder is stored on disk.
+const DefaultMaxMultipartMemory = 10 << 20 // 10MB
+
+// Option configures how a handler is run by StartWithOptions and the other Start functions.
+type Option func(*options)
+
+type options struct {
+	beforeHook         handler.BeforeHandlerHook
+	afterHook          handler.AfterHandlerHook
+	defaultHeaders     http.Header
+	binaryMediaTypes   []string
+	maxMultipartMemory int64
+	deadlineMargin     time.Duration
+	panicHook          handler.PanicHook
+	middleware         []handler.Middleware
+	responseHook       handler.ResponseHook
+	errorFormat        handler.ErrorFormat
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{
+		defaultHeaders:     http.Header{},
+		binaryMediaTypes:   DefaultBinaryMediaTypes,
+		maxMultipartMemory: DefaultMaxMultipartMemory,
+		deadlineMargin:     defaultDeadlineMargin,
+	}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithBeforeHook sets a hook which is run before the handler, the handler is skipped if it returns false.
+func WithBeforeHook(hook handler.BeforeHandlerHook) Option {
+	return func(o *options) {
+		o.beforeHook = hook
+	}
+}
+
+// WithAfterHook sets a hook which is run after the handler has written a 2xx response.
+func WithAfterHook(hook handler.AfterHandlerHook) Option {
+	return func(o *options) {
+		o.afterHook = hook
+	}
+}
+
+// WithDefaultHeaders sets the headers added to every response.
+func WithDefaultHeaders(headers http.Header) Option {
+	return func(o *options) {
+		if headers == nil {
+			headers = http.Header{}
+		}
+
+		o.defaultHeaders = headers
+	}
+}
+
+// WithBinaryTypes sets the response content types which are base64 encoded, replacing DefaultBinaryMediaTypes.
+func WithBinaryTypes(types ...string) Option {
+	return func(o *options) {
+		o.binaryMediaTypes = types
+	}
+}
+
+// WithMaxMultipartMemory sets the number of bytes of a multipart/form-data body held in memory.
+func WithMaxMultipartMemory(maxMemory int64) Option {
+	return func(o *options) {
+		o.maxMultipartMemory = maxMemory
+	}
+}
+
+// WithDeadlineMargin sets how long before the Lambda deadline the request context is cancelled, 500ms by default.
+func WithDeadlineMargin(margin time.Duration) Option {
+	return func(o *options) {
+		o.deadlineMargin = margin
+	}
+}
+
+// WithPanicHook sets a hook called whenever the handler panics, so panics can be reported to an error tracker.
+func WithPanicHook(hook handler.PanicHook) Option {
+	return func(o *options) {
+		o.panicHook = hook
+	}
+}
+
+// WithResponseHook sets a hook called after every response, including error, panic and timeout responses,
+// before it is turned into the Lambda event. Headers set by the hook are returned with the response.
+// It is not called by StartFunctionURLStreaming, whose headers are sent as soon as the handler writes.
+func WithResponseHook(hook handler.ResponseHook) Option {
+	return func(o *options) {
+		o.responseHook = hook
+	}
+}
+
+// WithErrorFormat sets the format errors are rendered in, service errors by default, unless the request's Accept header
+// asks for application/problem+json or refuses it with q=0.
+func WithErrorFormat(format handler.ErrorFormat) Option {
+	return func(o *options) {
+		o.errorFormat = format
+	}
+}
+
+// WithMiddleware appends middleware which wraps the handler, the first given being the outermost.
+// Middleware runs inside the before and after hooks.
+func WithMiddleware(mw ...handler.Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, mw...)
+	}
+}
+
+// wrap applies the before hook, after hook and middleware to h. As with mux, a panic in h is recovered inside
+// the middleware, so middleware sees the 500 response. It is passed to the panic hook followed by hooks.
+func (o *options) wrap(h http.HandlerFunc, hooks ...handler.PanicHook) http.Handler {
+	return handler.Chain(
+		append(
+			[]handler.Middleware{
+				handler.BeforeHookMiddleware(o.beforeHook),
+				handler.AfterHookMiddleware(o.afterHook),
+			},
+			o.middleware...,
+		)...,
+	)(handler.Recover(h, append([]handler.PanicHook{o.panicHook}, hooks...)...))
+}
+
+// afterResponse calls the response hook, if any, with the final response.
+func (o *options) afterResponse(resp *ResponseWriter, req *http.Request, start time.Time) *ResponseWriter {
+	if o.responseHook == nil {
+		return resp
+	}
+
+	status := resp.StatusCode
+	if status == 0 {
+		status = http.StatusOK
+	}
+
+	o.responseHook(resp, req, handler.ResponseInfo{
+		Status:  status,
+		Body:    resp.body.Bytes(),
+		Elapsed: time.Since(start),
+	})
+
+	return resp
+}
+
+// newResponseWriter creates a response writer for req configured with the default headers, binary media types
+// and the error format the request asks for.
+func (o *options) newResponseWriter(req *http.Request) *ResponseWriter {
+	resp := NewResponseWriter(o.defaultHeaders)
+	resp.SetBinaryMediaTypes(o.binaryMediaTypes)
+	resp.SetErrorFormat(handler.ErrorFormatFor(req, o.errorFormat), req.URL.Path)
+
+	return resp
+}
@@ -0,0 +1,202 @@
+package aws
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/stretchr/testify/suite"
+)
+
+type OptionsSuite struct {
+	suite.Suite
+}
+
+func (s *OptionsSuite) TestDefaults() {
+	o := newOptions()
+
+	s.Nil(o.beforeHook)
+	s.Nil(o.afterHook)
+	s.Equal(http.Header{}, o.defaultHeaders)
+	s.Equal(DefaultBinaryMediaTypes, o.binaryMediaTypes)
+	s.Equal(int64(DefaultMaxMultipartMemory), o.maxMultipartMemory)
+	s.Equal(defaultDeadlineMargin, o.deadlineMargin)
+}
+
+func (s *OptionsSuite) TestGetHandlerWithOptions() {
+	var recovered interface{}
+	o := newOptions(
+		WithDefaultHeaders(http.Header{"Content-Type": {"text/csv"}}),
+		WithBinaryTypes("text/csv"),
+		WithBeforeHook(func(res http.ResponseWriter, req *http.Request) bool {
+			res.Header().Set("X-Before", "true")
+			return true
+		}),
+		WithAfterHook(func(res http.ResponseWriter) {
+			res.Header().Set("X-After", "true")
+		}),
+		WithPanicHook(func(req *http.Request, r interface{}, stack []byte) {
+			recovered = r
+		}),
+	)
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("a,b"))
+	}
+
+	res, err := getHandler(h, o)(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.True(res.IsBase64Encoded)
+	s.Equal("true", res.Headers["X-Before"])
+	s.Equal("true", res.Headers["X-After"])
+
+	_, err = getHandler(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}, o)(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+	s.Equal("boom", recovered)
+}
+
+func (s *OptionsSuite) TestWithMiddleware() {
+	var calls []string
+	record := func(name string) handler.Middleware {
+		return func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls = append(calls, name)
+				next.ServeHTTP(w, r)
+			})
+		}
+	}
+
+	o := newOptions(
+		WithBeforeHook(func(res http.ResponseWriter, req *http.Request) bool {
+			calls = append(calls, "before")
+			return true
+		}),
+		WithMiddleware(record("first"), record("second")),
+	)
+
+	_, err := getHandler(func(w http.ResponseWriter, r *http.Request) {
+		calls = append(calls, "handler")
+		w.WriteHeader(http.StatusOK)
+	}, o)(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal([]string{"before", "first", "second", "handler"}, calls)
+}
+
+func (s *OptionsSuite) TestMiddlewareSeesPanic() {
+	var statuses []int
+	var recovered []interface{}
+	o := newOptions(
+		WithMiddleware(handler.ResponseHookMiddleware(func(res http.ResponseWriter, req *http.Request, info handler.ResponseInfo) {
+			statuses = append(statuses, info.Status)
+		})),
+		WithPanicHook(func(req *http.Request, r interface{}, stack []byte) {
+			recovered = append(recovered, r)
+		}),
+	)
+
+	res, err := getHandler(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}, o)(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusInternalServerError, res.StatusCode)
+	s.Equal([]int{http.StatusInternalServerError}, statuses)
+	s.Equal([]interface{}{"boom"}, recovered)
+
+	res, err = getHandler(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}, newOptions(WithMiddleware(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r)
+			panic("boom")
+		})
+	})))(context.Background(), &events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/",
+	})
+	s.NoError(err)
+
+	s.Equal(http.StatusInternalServerError, res.StatusCode)
+}
+
+func (s *OptionsSuite) TestWithResponseHook() {
+	var infos []handler.ResponseInfo
+	var paths []string
+	o := newOptions(
+		WithBeforeHook(func(res http.ResponseWriter, req *http.Request) bool {
+			if req.Header.Get("Authorization") == "" {
+				res.WriteHeader(http.StatusUnauthorized)
+				return false
+			}
+
+			return true
+		}),
+		WithResponseHook(func(res http.ResponseWriter, req *http.Request, info handler.ResponseInfo) {
+			infos = append(infos, info)
+			paths = append(paths, req.URL.Path)
+			res.Header().Set("Access-Control-Allow-Origin", "*")
+		}),
+	)
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/panic" {
+			panic("boom")
+		}
+
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(`{"id":"1"}`))
+	}
+
+	authorized := map[string]string{"Authorization": "authToken"}
+	for _, tc := range []struct {
+		path    string
+		headers map[string]string
+		status  int
+		body    string
+	}{
+		{path: "/products", headers: authorized, status: http.StatusCreated, body: `{"id":"1"}`},
+		{path: "/products", status: http.StatusUnauthorized, body: ""},
+		{path: "/panic", headers: authorized, status: http.StatusInternalServerError, body: `{"error":{"id":"INTERNAL_SERVER_ERROR","code":"INTERNAL_SERVER_ERROR","message":"An internal error occurred"}}`},
+	} {
+		res, err := getHandler(h, o)(context.Background(), &events.APIGatewayProxyRequest{
+			HTTPMethod: http.MethodPost,
+			Path:       tc.path,
+			Headers:    tc.headers,
+		})
+		s.NoError(err)
+
+		s.Equal(tc.status, res.StatusCode)
+		s.Equal("*", res.Headers["Access-Control-Allow-Origin"])
+
+		info := infos[len(infos)-1]
+		s.Equal(tc.path, paths[len(paths)-1])
+		s.Equal(tc.status, info.Status)
+		s.Equal(tc.body, string(info.Body))
+		s.Positive(info.Elapsed)
+	}
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestOptionsSuite(t *testing.T) {
+	suite.Run(t, new(OptionsSuite))
+}
@@ -5,7 +5,6 @@ import (
 	"encoding/base64"
 	"errors"
 	"fmt"
-	"io"
 	"mime"
 	"mime/multipart"
 	"net/http"
@@ -22,6 +21,10 @@ var (
 )
 
 func NewHttpRequest(r *events.APIGatewayProxyRequest) (*http.Request, error) {
+	return newHttpRequest(r, DefaultMaxMultipartMemory)
+}
+
+func newHttpRequest(r *events.APIGatewayProxyRequest, maxMultipartMemory int64) (*http.Request, error) {
 	scheme := "https"
 	if v, ok := r.Headers["X-Forwarded-Proto"]; ok {
 		scheme = v
@@ -37,9 +40,12 @@ func NewHttpRequest(r *events.APIGatewayProxyRequest) (*http.Request, error) {
 		return nil, err
 	}
 
+	// API Gateway sends every parameter in both maps, the single value map only holding the last value.
 	q := url.Values{}
 	for k, v := range r.QueryStringParameters {
-		q.Add(k, v)
+		if _, ok := r.MultiValueQueryStringParameters[k]; !ok {
+			q.Add(k, v)
+		}
 	}
 
 	for k, vals := range r.MultiValueQueryStringParameters {
@@ -50,18 +56,12 @@ func NewHttpRequest(r *events.APIGatewayProxyRequest) (*http.Request, error) {
 
 	parsedUrl.RawQuery = q.Encode()
 
-	var body io.ReadCloser
-	if r.IsBase64Encoded {
-		decodedBody, err := base64.StdEncoding.DecodeString(r.Body)
-		if err != nil {
-			return nil, err
-		}
-		body = io.NopCloser(bytes.NewReader(decodedBody))
-	} else {
-		body = io.NopCloser(strings.NewReader(r.Body))
+	body, err := decodeBody(r.Body, r.IsBase64Encoded)
+	if err != nil {
+		return nil, err
 	}
 
-	req, err := http.NewRequest(r.HTTPMethod, parsedUrl.String(), body)
+	req, err := http.NewRequest(r.HTTPMethod, parsedUrl.String(), bytes.NewReader(body))
 	if err != nil {
 		return nil, err
 	}
@@ -73,7 +73,9 @@ func NewHttpRequest(r *events.APIGatewayProxyRequest) (*http.Request, error) {
 	}
 
 	for key, value := range r.Headers {
-		req.Header.Set(key, value)
+		if _, ok := r.MultiValueHeaders[key]; !ok {
+			req.Header.Set(key, value)
+		}
 	}
 
 	if r.RequestContext.Identity.SourceIP != "" {
@@ -87,24 +89,44 @@ func NewHttpRequest(r *events.APIGatewayProxyRequest) (*http.Request, error) {
 		req.Header.Set("User-Agent", userAgent)
 	}
 
+	if err := parseMultipartForm(req, body, maxMultipartMemory); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// decodeBody returns the raw bytes of an event body, decoding it when API Gateway has base64 encoded it.
+func decodeBody(body string, isBase64Encoded bool) ([]byte, error) {
+	if isBase64Encoded {
+		return base64.StdEncoding.DecodeString(body)
+	}
+
+	return []byte(body), nil
+}
+
+// parseMultipartForm populates req.MultipartForm when the request carries a multipart/form-data body.
+func parseMultipartForm(req *http.Request, body []byte, maxMemory int64) error {
 	contentType := req.Header.Get("Content-Type")
-	if strings.HasPrefix(contentType, "multipart/form-data") {
-		mediaType, params, err := mime.ParseMediaType(contentType)
-		if err != nil {
-			return nil, err
-		}
+	if !strings.HasPrefix(contentType, "multipart/form-data") {
+		return nil
+	}
 
-		if strings.HasPrefix(mediaType, "multipart/") {
-			mr := multipart.NewReader(body, params["boundary"])
+	mediaType, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return err
+	}
 
-			multipartForm, err := mr.ReadForm(10 << 20) // 10MB max memory for the form
-			if err != nil {
-				return nil, err
-			}
+	if strings.HasPrefix(mediaType, "multipart/") {
+		mr := multipart.NewReader(bytes.NewReader(body), params["boundary"])
 
-			req.MultipartForm = multipartForm
+		multipartForm, err := mr.ReadForm(maxMemory)
+		if err != nil {
+			return err
 		}
+
+		req.MultipartForm = multipartForm
 	}
 
-	return req, nil
+	return nil
 }
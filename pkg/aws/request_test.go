@@ -126,6 +126,17 @@ func (s *RequestSuite) TestNewHttpRequestMultipartForm() {
 	}
 }
 
+func (s *RequestSuite) TestNewHttpRequestBothValueMaps() {
+	s.req.Headers["X-Custom-Header"] = "value2"
+	s.req.QueryStringParameters["extend"] = "tabs"
+
+	req, err := NewHttpRequest(s.req)
+	s.NoError(err)
+
+	s.Equal([]string{"value1", "value2"}, req.Header.Values("X-Custom-Header"))
+	s.Equal([]string{"attributes", "tabs"}, req.URL.Query()["extend"])
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestRequestSuite(t *testing.T) {
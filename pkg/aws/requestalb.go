@@ -0,0 +1,94 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NewHttpRequestALB converts an Application Load Balancer target group event into a http.Request.
+// The load balancer passes query strings through without decoding them, so they are unescaped here.
+func NewHttpRequestALB(r *events.ALBTargetGroupRequest) (*http.Request, error) {
+	return newHttpRequestALB(r, DefaultMaxMultipartMemory)
+}
+
+func newHttpRequestALB(r *events.ALBTargetGroupRequest, maxMultipartMemory int64) (*http.Request, error) {
+	headers := http.Header{}
+	for key, values := range r.MultiValueHeaders {
+		for _, value := range values {
+			headers.Add(key, value)
+		}
+	}
+
+	for key, value := range r.Headers {
+		headers.Set(key, value)
+	}
+
+	scheme := "https"
+	if v := headers.Get("X-Forwarded-Proto"); v != "" {
+		scheme = v
+	}
+
+	host := "example.com"
+	if v := headers.Get("Host"); v != "" {
+		host = v
+	}
+
+	parsedUrl, err := url.Parse(fmt.Sprintf("%s://%s%s", scheme, host, r.Path))
+	if err != nil {
+		return nil, err
+	}
+
+	q := url.Values{}
+	for k, v := range r.QueryStringParameters {
+		q.Add(unescapeALBQuery(k), unescapeALBQuery(v))
+	}
+
+	for k, vals := range r.MultiValueQueryStringParameters {
+		for _, v := range vals {
+			q.Add(unescapeALBQuery(k), unescapeALBQuery(v))
+		}
+	}
+
+	parsedUrl.RawQuery = q.Encode()
+
+	body, err := decodeBody(r.Body, r.IsBase64Encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(r.HTTPMethod, parsedUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = headers
+
+	// The load balancer appends to X-Forwarded-For, so the client is the left-most address.
+	if v := headers.Get("X-Forwarded-For"); v != "" {
+		client, _, _ := strings.Cut(v, ",")
+		req.RemoteAddr = strings.TrimSpace(client)
+		if p := headers.Get("X-Forwarded-Port"); p != "" {
+			req.RemoteAddr = net.JoinHostPort(req.RemoteAddr, p)
+		}
+	}
+
+	if err := parseMultipartForm(req, body, maxMultipartMemory); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func unescapeALBQuery(s string) string {
+	if v, err := url.QueryUnescape(s); err == nil {
+		return v
+	}
+
+	return s
+}
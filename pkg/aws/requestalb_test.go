@@ -0,0 +1,95 @@
+package aws
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestALBSuite struct {
+	suite.Suite
+	req *events.ALBTargetGroupRequest
+}
+
+func (s *RequestALBSuite) SetupTest() {
+	s.req = &events.ALBTargetGroupRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/products/ABC123",
+		QueryStringParameters: map[string]string{
+			"search": "red%20shoes",
+			"locale": "en-GB",
+		},
+		Headers: map[string]string{
+			"host":              "example.com",
+			"x-forwarded-proto": "https",
+			"x-forwarded-for":   "127.0.0.1",
+			"authorization":     "Bearer example-token",
+		},
+		RequestContext: events.ALBTargetGroupRequestContext{
+			ELB: events.ELBContext{
+				TargetGroupArn: "arn:aws:elasticloadbalancing:eu-west-1:1234567890:targetgroup/example/0000000000000000",
+			},
+		},
+		Body:            "{\"name\": \"Example Product\"}",
+		IsBase64Encoded: false,
+	}
+}
+
+func (s *RequestALBSuite) TestNewHttpRequestALB() {
+	req, err := NewHttpRequestALB(s.req)
+	s.NoError(err)
+
+	s.Equal(http.MethodGet, req.Method)
+	s.Equal("https", req.URL.Scheme)
+	s.Equal("example.com", req.URL.Host)
+	s.Equal("/products/ABC123", req.URL.Path)
+	s.Equal("red shoes", req.URL.Query().Get("search"))
+	s.Equal("en-GB", req.URL.Query().Get("locale"))
+	s.Equal("Bearer example-token", req.Header.Get("Authorization"))
+
+	b, err := io.ReadAll(req.Body)
+	s.NoError(err)
+	s.Equal(s.req.Body, string(b))
+
+	s.Equal("127.0.0.1", req.RemoteAddr)
+}
+
+func (s *RequestALBSuite) TestNewHttpRequestALBMultiValue() {
+	s.req.Headers = nil
+	s.req.QueryStringParameters = nil
+	s.req.MultiValueHeaders = map[string][]string{
+		"host":            {"example.com"},
+		"x-custom-header": {"value1", "value2"},
+	}
+	s.req.MultiValueQueryStringParameters = map[string][]string{
+		"extend": {"attributes", "tabs"},
+	}
+
+	req, err := NewHttpRequestALB(s.req)
+	s.NoError(err)
+
+	s.Equal("example.com", req.URL.Host)
+	s.Equal([]string{"value1", "value2"}, req.Header.Values("X-Custom-Header"))
+	s.Equal("extend=attributes&extend=tabs", req.URL.RawQuery)
+}
+
+func (s *RequestALBSuite) TestNewHttpRequestALBForwardedChain() {
+	s.req.Headers["x-forwarded-for"] = "203.0.113.7, 10.0.0.1, 10.0.0.2"
+	s.req.Headers["x-forwarded-port"] = "443"
+
+	req, err := NewHttpRequestALB(s.req)
+	s.NoError(err)
+
+	s.Equal("203.0.113.7:443", req.RemoteAddr)
+	s.Equal("203.0.113.7", handler.NewContext(req).SourceIP())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRequestALBSuite(t *testing.T) {
+	suite.Run(t, new(RequestALBSuite))
+}
@@ -0,0 +1,42 @@
+package aws
+
+import (
+	"net/http"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NewHttpRequestFunctionURL converts a Lambda Function URL event into a http.Request.
+// Function URLs share the API Gateway payload format 2.0, so the conversion is delegated to NewHttpRequestV2.
+func NewHttpRequestFunctionURL(r *events.LambdaFunctionURLRequest) (*http.Request, error) {
+	return newHttpRequestFunctionURL(r, DefaultMaxMultipartMemory)
+}
+
+func newHttpRequestFunctionURL(r *events.LambdaFunctionURLRequest, maxMultipartMemory int64) (*http.Request, error) {
+	return newHttpRequestV2(&events.APIGatewayV2HTTPRequest{
+		Version:               r.Version,
+		RawPath:               r.RawPath,
+		RawQueryString:        r.RawQueryString,
+		Cookies:               r.Cookies,
+		Headers:               r.Headers,
+		QueryStringParameters: r.QueryStringParameters,
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			AccountID:    r.RequestContext.AccountID,
+			RequestID:    r.RequestContext.RequestID,
+			APIID:        r.RequestContext.APIID,
+			DomainName:   r.RequestContext.DomainName,
+			DomainPrefix: r.RequestContext.DomainPrefix,
+			Time:         r.RequestContext.Time,
+			TimeEpoch:    r.RequestContext.TimeEpoch,
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:    r.RequestContext.HTTP.Method,
+				Path:      r.RequestContext.HTTP.Path,
+				Protocol:  r.RequestContext.HTTP.Protocol,
+				SourceIP:  r.RequestContext.HTTP.SourceIP,
+				UserAgent: r.RequestContext.HTTP.UserAgent,
+			},
+		},
+		Body:            r.Body,
+		IsBase64Encoded: r.IsBase64Encoded,
+	}, maxMultipartMemory)
+}
@@ -0,0 +1,75 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// NewHttpRequestV2 converts an API Gateway HTTP API (payload format 2.0) event into a http.Request.
+func NewHttpRequestV2(r *events.APIGatewayV2HTTPRequest) (*http.Request, error) {
+	return newHttpRequestV2(r, DefaultMaxMultipartMemory)
+}
+
+func newHttpRequestV2(r *events.APIGatewayV2HTTPRequest, maxMultipartMemory int64) (*http.Request, error) {
+	headers := http.Header{}
+	for key, value := range r.Headers {
+		headers.Set(key, value)
+	}
+
+	if len(r.Cookies) > 0 {
+		headers.Set("Cookie", strings.Join(r.Cookies, "; "))
+	}
+
+	scheme := "https"
+	if v := headers.Get("X-Forwarded-Proto"); v != "" {
+		scheme = v
+	}
+
+	host := "example.com"
+	if v := headers.Get("Host"); v != "" {
+		host = v
+	} else if r.RequestContext.DomainName != "" {
+		host = r.RequestContext.DomainName
+	}
+
+	parsedUrl, err := url.Parse(fmt.Sprintf("%s://%s%s", scheme, host, r.RawPath))
+	if err != nil {
+		return nil, err
+	}
+
+	parsedUrl.RawQuery = r.RawQueryString
+
+	body, err := decodeBody(r.Body, r.IsBase64Encoded)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest(r.RequestContext.HTTP.Method, parsedUrl.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header = headers
+
+	if r.RequestContext.HTTP.SourceIP != "" {
+		req.RemoteAddr = r.RequestContext.HTTP.SourceIP
+		if v := headers.Get("X-Forwarded-Port"); v != "" {
+			req.RemoteAddr = fmt.Sprintf("%s:%s", req.RemoteAddr, v)
+		}
+	}
+
+	if userAgent := r.RequestContext.HTTP.UserAgent; userAgent != "" {
+		req.Header.Set("User-Agent", userAgent)
+	}
+
+	if err := parseMultipartForm(req, body, maxMultipartMemory); err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,113 @@
+package aws
+
+import (
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestV2Suite struct {
+	suite.Suite
+	req *events.APIGatewayV2HTTPRequest
+}
+
+func (s *RequestV2Suite) SetupTest() {
+	s.req = &events.APIGatewayV2HTTPRequest{
+		Version:        "2.0",
+		RouteKey:       "PUT /products/{id}",
+		RawPath:        "/products/ABC123",
+		RawQueryString: "extend=attributes&extend=tabs&locale=en-GB",
+		Cookies: []string{
+			"session=abc",
+			"theme=dark",
+		},
+		Headers: map[string]string{
+			"host":              "example.com",
+			"x-forwarded-proto": "https",
+			"content-type":      "application/json",
+			"authorization":     "Bearer example-token",
+			"x-custom-header":   "value1,value2",
+		},
+		QueryStringParameters: map[string]string{
+			"extend": "attributes,tabs",
+			"locale": "en-GB",
+		},
+		PathParameters: map[string]string{
+			"id": "ABC123",
+		},
+		RequestContext: events.APIGatewayV2HTTPRequestContext{
+			RouteKey:   "PUT /products/{id}",
+			AccountID:  "1234567890",
+			Stage:      "$default",
+			RequestID:  "00000000-0000-0000-0000-000000000000",
+			APIID:      "api-id",
+			DomainName: "api-id.execute-api.eu-west-1.amazonaws.com",
+			HTTP: events.APIGatewayV2HTTPRequestContextHTTPDescription{
+				Method:    http.MethodPut,
+				Path:      "/products/ABC123",
+				Protocol:  "HTTP/1.1",
+				SourceIP:  "127.0.0.1",
+				UserAgent: "Mozilla/5.0 (compatible; Example/0.1; +http://example.com)",
+			},
+		},
+		Body:            "{\"name\": \"Example Product\"}",
+		IsBase64Encoded: false,
+	}
+}
+
+func (s *RequestV2Suite) TestNewHttpRequestV2() {
+	req, err := NewHttpRequestV2(s.req)
+	s.NoError(err)
+
+	s.Equal(http.MethodPut, req.Method)
+	s.Equal("https", req.URL.Scheme)
+	s.Equal("example.com", req.URL.Host)
+	s.Equal("/products/ABC123", req.URL.Path)
+	s.Equal("extend=attributes&extend=tabs&locale=en-GB", req.URL.RawQuery)
+	s.Equal([]string{"attributes", "tabs"}, req.URL.Query()["extend"])
+
+	s.Equal("Bearer example-token", req.Header.Get("Authorization"))
+	s.Equal("value1,value2", req.Header.Get("X-Custom-Header"))
+	s.Equal("Mozilla/5.0 (compatible; Example/0.1; +http://example.com)", req.UserAgent())
+
+	cookie, err := req.Cookie("theme")
+	s.NoError(err)
+	s.Equal("dark", cookie.Value)
+	s.Len(req.Cookies(), 2)
+
+	b, err := io.ReadAll(req.Body)
+	s.NoError(err)
+	s.Equal(s.req.Body, string(b))
+
+	s.Equal("example.com", req.Host)
+	s.Equal("127.0.0.1", req.RemoteAddr)
+}
+
+func (s *RequestV2Suite) TestNewHttpRequestV2DomainName() {
+	delete(s.req.Headers, "host")
+
+	req, err := NewHttpRequestV2(s.req)
+	s.NoError(err)
+
+	s.Equal("api-id.execute-api.eu-west-1.amazonaws.com", req.URL.Host)
+}
+
+func (s *RequestV2Suite) TestNewHttpRequestV2EncodedBody() {
+	s.req.Body = "eyJuYW1lIjogIkV4YW1wbGUgUHJvZHVjdCJ9"
+	s.req.IsBase64Encoded = true
+	req, err := NewHttpRequestV2(s.req)
+	s.NoError(err)
+
+	b, err := io.ReadAll(req.Body)
+	s.NoError(err)
+	s.Equal("{\"name\": \"Example Product\"}", string(b))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRequestV2Suite(t *testing.T) {
+	suite.Run(t, new(RequestV2Suite))
+}
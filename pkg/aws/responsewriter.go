@@ -1,35 +1,143 @@
 package aws
 
 import (
+	"bytes"
+	"encoding/base64"
 	"encoding/json"
 	"log/slog"
+	"mime"
 	"net/http"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/aws/aws-lambda-go/events"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
 	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
 )
 
+// DefaultBinaryMediaTypes are the response content types which are base64 encoded by default.
+// Like API Gateway's binaryMediaTypes setting, entries may use a wildcard subtype (e.g. image/*) or */*.
+var DefaultBinaryMediaTypes = []string{
+	"application/octet-stream",
+	"application/pdf",
+	"application/zip",
+	"application/gzip",
+	"image/*",
+	"audio/*",
+	"video/*",
+	"font/*",
+}
+
+// ResponseWriter is a http.ResponseWriter which collects the response for returning as a Lambda event.
+// Writes are buffered and only turned into the event body by Finalize, which the NewEvent functions call.
+// Only one response can be written. A second WriteHeader is logged and ignored, and when the first status was also set
+// by WriteHeader the writes following it are discarded, as they belong to a second response.
 type ResponseWriter struct {
 	*events.APIGatewayProxyResponse
-	defaulHeaders http.Header
+	defaulHeaders    http.Header
+	binaryMediaTypes []string
+	body             bytes.Buffer
+	written          bool
+	implicitStatus   bool
+	discarding       bool
+	errorFormat      handler.ErrorFormat
+	errorInstance    string
 }
 
+// NewResponseWriter creates a response writer starting from a copy of the given default headers,
+// so headers set by one invocation never leak into the defaults used by the next.
 func NewResponseWriter(headers http.Header) *ResponseWriter {
+	if headers == nil {
+		headers = http.Header{}
+	}
+
 	return &ResponseWriter{
 		APIGatewayProxyResponse: &events.APIGatewayProxyResponse{},
-		defaulHeaders:           headers,
+		defaulHeaders:           headers.Clone(),
+		binaryMediaTypes:        DefaultBinaryMediaTypes,
 	}
 }
 
+// newServiceErrorResponseWriter builds a response writer holding err, used when the handler's own response has to be discarded.
+func newServiceErrorResponseWriter(o *options, req *http.Request, err *serviceerror.ServiceError) *ResponseWriter {
+	resp := o.newResponseWriter(req)
+	handler.WriteError(resp, err)
+
+	return resp
+}
+
+// SetBinaryMediaTypes replaces the content types which are base64 encoded when written.
+func (w *ResponseWriter) SetBinaryMediaTypes(types []string) {
+	w.binaryMediaTypes = types
+}
+
+// SetErrorFormat sets how a non-2xx body which isn't already a JSON object is wrapped by Finalize,
+// and how handler.ResponseHandler renders errors written to w. instance is reported in a problem.
+func (w *ResponseWriter) SetErrorFormat(format handler.ErrorFormat, instance string) {
+	w.errorFormat = format
+	w.errorInstance = instance
+}
+
+// ErrorFormat implements handler.ErrorFormatter.
+func (w *ResponseWriter) ErrorFormat() (handler.ErrorFormat, string) {
+	return w.errorFormat, w.errorInstance
+}
+
 func (w *ResponseWriter) Header() http.Header {
 	return w.defaulHeaders
 }
 
+// Write appends body to the response. As with net/http, the status defaults to 200 if WriteHeader has not been called.
 func (w *ResponseWriter) Write(body []byte) (int, error) {
+	if w.discarding {
+		return len(body), nil
+	}
+
+	if w.StatusCode == 0 {
+		w.StatusCode = http.StatusOK
+		w.implicitStatus = true
+	}
+
+	w.written = true
+
+	return w.body.Write(body)
+}
+
+// WriteHeader sets the response status. Once set, later calls are ignored, and if it was set by WriteHeader rather
+// than by Write they start a second response which is discarded.
+func (w *ResponseWriter) WriteHeader(statusCode int) {
+	if w.StatusCode != 0 {
+		slog.Warn("ignoring second response written by handler", "status", w.StatusCode, "ignored", statusCode)
+		if !w.implicitStatus {
+			w.discarding = true
+		}
+
+		return
+	}
+
+	w.StatusCode = statusCode
+}
+
+// Finalize sets the event Body from everything written so far.
+// Binary content is base64 encoded, and a non-2xx body which isn't already a JSON object is wrapped in a service error,
+// or a problem if the error format is handler.ProblemFormat.
+func (w *ResponseWriter) Finalize() {
+	if w.StatusCode == 0 {
+		w.StatusCode = http.StatusOK
+	}
+
+	body := w.body.Bytes()
+	if w.isBinary(body) {
+		w.Body = base64.StdEncoding.EncodeToString(body)
+		w.IsBase64Encoded = true
+
+		return
+	}
+
 	bodyStr := string(body)
-	if !isOkRange(w.StatusCode) && !isValidJSONObject(bodyStr) {
+	if w.written && !isOkRange(w.StatusCode) && !isValidJSONObject(bodyStr) {
 		var decodedString string
-		if err := json.Unmarshal([]byte(bodyStr), &decodedString); err == nil {
+		if err := json.Unmarshal(body, &decodedString); err == nil {
 			bodyStr = decodedString
 		}
 
@@ -39,22 +147,62 @@ func (w *ResponseWriter) Write(body []byte) (int, error) {
 			bodyStr,
 		)
 
-		b, err := json.Marshal(e)
-		if err != nil {
-			slog.Error(err.Error())
-			return 0, err
+		var wrapped interface{} = e
+		if w.errorFormat == handler.ProblemFormat {
+			wrapped = e.Problem(w.errorInstance)
+			w.Header().Set("Content-Type", serviceerror.ProblemContentType)
 		}
 
-		bodyStr = string(b)
+		if b, err := json.Marshal(wrapped); err != nil {
+			slog.Error(err.Error())
+		} else {
+			bodyStr = string(b)
+		}
 	}
 
 	w.Body = bodyStr
+	w.IsBase64Encoded = false
+}
+
+// isBinary reports whether body must be base64 encoded to survive the trip through API Gateway.
+// This is the case for encoded (e.g. gzip) content, configured binary media types and anything that is not valid UTF-8.
+func (w *ResponseWriter) isBinary(body []byte) bool {
+	h := w.Header()
+	if h != nil {
+		if enc := h.Get("Content-Encoding"); enc != "" && enc != "identity" {
+			return true
+		}
+
+		if isBinaryMediaType(h.Get("Content-Type"), w.binaryMediaTypes) {
+			return true
+		}
+	}
 
-	return len(body), nil
+	return !utf8.Valid(body)
 }
 
-func (w *ResponseWriter) WriteHeader(statusCode int) {
-	w.StatusCode = statusCode
+func isBinaryMediaType(contentType string, binaryMediaTypes []string) bool {
+	if contentType == "" {
+		return false
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, t := range binaryMediaTypes {
+		t = strings.ToLower(t)
+		if t == "*/*" || t == mediaType {
+			return true
+		}
+
+		if prefix, ok := strings.CutSuffix(t, "/*"); ok && strings.HasPrefix(mediaType, prefix+"/") {
+			return true
+		}
+	}
+
+	return false
 }
 
 func isValidJSONObject(s string) bool {
@@ -1,9 +1,15 @@
 package aws
 
 import (
+	"encoding/base64"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -30,6 +36,7 @@ func (s *ResponseWriterSuite) TestNewResponseWriter() {
 	s.Equal(http.StatusOK, r.StatusCode)
 
 	r.Write([]byte("foo"))
+	r.Finalize()
 	s.Equal("foo", r.Body)
 }
 
@@ -43,6 +50,7 @@ func (s *ResponseWriterSuite) TestErrorResponse() {
 	s.Equal(http.StatusBadRequest, r.StatusCode)
 
 	r.Write([]byte("Oops"))
+	r.Finalize()
 	s.Equal("{\"error\":{\"id\":\"BAD_REQUEST\",\"code\":\"BAD_REQUEST\",\"message\":\"Oops\"}}", r.Body)
 }
 
@@ -56,6 +64,7 @@ func (s *ResponseWriterSuite) TestWrapperString() {
 	s.Equal(http.StatusBadRequest, r.StatusCode)
 
 	r.Write([]byte("\"Oops\"\n"))
+	r.Finalize()
 	s.Equal("{\"error\":{\"id\":\"BAD_REQUEST\",\"code\":\"BAD_REQUEST\",\"message\":\"Oops\"}}", r.Body)
 }
 
@@ -70,6 +79,150 @@ func (s *ResponseWriterSuite) TestAddHeader() {
 	s.Equal("bar", r.Header().Get("foo"))
 }
 
+func (s *ResponseWriterSuite) TestBinaryContentType() {
+	r := NewResponseWriter(http.Header{
+		"Content-Type": []string{"application/pdf"},
+	})
+
+	r.WriteHeader(http.StatusOK)
+	r.Write([]byte("%PDF-1.7"))
+	r.Finalize()
+	s.True(r.IsBase64Encoded)
+	s.Equal(base64.StdEncoding.EncodeToString([]byte("%PDF-1.7")), r.Body)
+}
+
+func (s *ResponseWriterSuite) TestBinaryWildcardContentType() {
+	r := NewResponseWriter(http.Header{
+		"Content-Type": []string{"image/png"},
+	})
+
+	r.WriteHeader(http.StatusOK)
+	r.Write([]byte("png"))
+	r.Finalize()
+	s.True(r.IsBase64Encoded)
+}
+
+func (s *ResponseWriterSuite) TestBinaryContentEncoding() {
+	s.headers.Set("Content-Encoding", "gzip")
+	r := NewResponseWriter(s.headers)
+
+	r.WriteHeader(http.StatusOK)
+	r.Write([]byte("gzipped"))
+	r.Finalize()
+	s.True(r.IsBase64Encoded)
+}
+
+func (s *ResponseWriterSuite) TestBinaryInvalidUTF8() {
+	body := []byte{0xff, 0xfe, 0xfd}
+	r := NewResponseWriter(s.headers)
+
+	r.WriteHeader(http.StatusOK)
+	r.Write(body)
+	r.Finalize()
+	s.True(r.IsBase64Encoded)
+	s.Equal(base64.StdEncoding.EncodeToString(body), r.Body)
+}
+
+func (s *ResponseWriterSuite) TestSetBinaryMediaTypes() {
+	r := NewResponseWriter(http.Header{
+		"Content-Type": []string{"text/csv; charset=utf-8"},
+	})
+	r.SetBinaryMediaTypes([]string{"text/csv"})
+
+	r.WriteHeader(http.StatusOK)
+	r.Write([]byte("a,b"))
+	r.Finalize()
+	s.True(r.IsBase64Encoded)
+
+	r.SetBinaryMediaTypes(nil)
+	r.Finalize()
+	s.False(r.IsBase64Encoded)
+	s.Equal("a,b", r.Body)
+}
+
+func (s *ResponseWriterSuite) TestDefaultHeadersAreCopied() {
+	r := NewResponseWriter(s.headers)
+
+	r.Header().Add("Content-Type", "text/plain")
+	r.Header().Set("foo", "bar")
+
+	s.Equal(http.Header{"Content-Type": {"application/json; charset=utf-8"}}, s.headers)
+}
+
+func (s *ResponseWriterSuite) TestWriteAppends() {
+	r := NewResponseWriter(s.headers)
+
+	s.NoError(json.NewEncoder(r).Encode(map[string]bool{"success": true}))
+	io.Copy(r, strings.NewReader("chunk"))
+	r.Finalize()
+
+	s.Equal(http.StatusOK, r.StatusCode)
+	s.Equal("{\"success\":true}\nchunk", r.Body)
+}
+
+func (s *ResponseWriterSuite) TestErrorResponseChunked() {
+	r := NewResponseWriter(s.headers)
+
+	r.WriteHeader(http.StatusNotFound)
+	r.Write([]byte("Not "))
+	r.Write([]byte("here"))
+	r.Finalize()
+
+	s.Equal("{\"error\":{\"id\":\"NOT_FOUND\",\"code\":\"NOT_FOUND\",\"message\":\"Not here\"}}", r.Body)
+}
+
+func (s *ResponseWriterSuite) TestImplicitStatus() {
+	r := NewResponseWriter(s.headers)
+	r.Finalize()
+
+	s.Equal(http.StatusOK, r.StatusCode)
+	s.Equal("", r.Body)
+}
+
+func (s *ResponseWriterSuite) TestSecondResponseIgnored() {
+	r := NewResponseWriter(s.headers)
+
+	r.WriteHeader(http.StatusUnauthorized)
+	r.Write([]byte(`{"error":{"id":"UNAUTHORIZED","code":"UNAUTHORIZED","message":"Unauthorized"}}`))
+	r.WriteHeader(http.StatusOK)
+	r.Write([]byte(`{"success":true}`))
+	r.Finalize()
+
+	s.Equal(http.StatusUnauthorized, r.StatusCode)
+	s.Equal(`{"error":{"id":"UNAUTHORIZED","code":"UNAUTHORIZED","message":"Unauthorized"}}`, r.Body)
+}
+
+func (s *ResponseWriterSuite) TestWriteHeaderAfterImplicitStatusKeepsBody() {
+	r := NewResponseWriter(s.headers)
+
+	r.Write([]byte(`{"a":`))
+	r.WriteHeader(http.StatusInternalServerError)
+	r.Write([]byte(`1}`))
+	r.Finalize()
+
+	s.Equal(http.StatusOK, r.StatusCode)
+	s.Equal(`{"a":1}`, r.Body)
+}
+
+func (s *ResponseWriterSuite) TestErrorResponseProblemFormat() {
+	r := NewResponseWriter(s.headers)
+	r.SetErrorFormat(handler.ProblemFormat, "/products/ABC123")
+
+	r.WriteHeader(http.StatusNotFound)
+	r.Write([]byte("Not here"))
+	r.Finalize()
+
+	s.Equal(serviceerror.ProblemContentType, r.Header().Get("Content-Type"))
+	s.JSONEq(`{
+		"type":"about:blank",
+		"title":"Not Found",
+		"status":404,
+		"detail":"Not here",
+		"instance":"/products/ABC123",
+		"code":"NOT_FOUND"
+	}`, r.Body)
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestResponseWriterSuite(t *testing.T) {
@@ -0,0 +1,228 @@
+package aws
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+type LambdaCallbackFunctionURLStreaming = func(ctx context.Context, request *events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLStreamingResponse, error)
+
+// StartFunctionURLStreaming runs the handler behind a Lambda Function URL configured with the RESPONSE_STREAM invoke mode.
+// The status and headers are sent as soon as the handler calls WriteHeader, Write or Flush, and the body is streamed
+// to the client each time the handler calls Flush (or the write buffer fills up).
+func StartFunctionURLStreaming(h http.HandlerFunc, opts ...Option) {
+	lambda.Start(
+		getHandlerFunctionURLStreaming(h, newOptions(opts...)),
+	)
+}
+
+func getHandlerFunctionURLStreaming(h http.HandlerFunc, o *options) LambdaCallbackFunctionURLStreaming {
+	return func(ctx context.Context, r *events.LambdaFunctionURLRequest) (*events.LambdaFunctionURLStreamingResponse, error) {
+		req, err := newHttpRequestFunctionURL(r, o.maxMultipartMemory)
+		if err != nil {
+			return nil, err
+		}
+
+		cancel := context.CancelFunc(func() {})
+		if deadline, ok := ctx.Deadline(); ok {
+			ctx, cancel = context.WithDeadline(ctx, deadline.Add(-o.deadlineMargin))
+		}
+		req = req.WithContext(withFunctionURLRequestContext(ctx, r.RequestContext))
+
+		pr, pw := io.Pipe()
+		resp := newStreamingResponseWriter(o.defaultHeaders, pw)
+		resp.errorFormat, resp.errorInstance = handler.ErrorFormatFor(req, o.errorFormat), req.URL.Path
+		failed := make(chan *serviceerror.ServiceError, 1)
+
+		// A panic once the status has been sent can't be answered with a 500, all that can be done is to cut the stream short.
+		var cut error
+		wrapped := o.wrap(h, func(_ *http.Request, recovered interface{}, _ []byte) {
+			if resp.isCommitted() {
+				cut = fmt.Errorf("handler panicked: %v", recovered)
+			}
+		})
+
+		go func() {
+			// The handler keeps streaming after the callback has returned, so its context lasts until it finishes.
+			defer cancel()
+			defer func() {
+				if recovered := recover(); recovered != nil {
+					handler.HandlePanic(req, recovered, o.panicHook)
+
+					if resp.isCommitted() {
+						pw.CloseWithError(fmt.Errorf("handler panicked: %v", recovered))
+					} else {
+						failed <- handler.PanicError()
+					}
+
+					return
+				}
+
+				if cut != nil {
+					pw.CloseWithError(cut)
+					return
+				}
+
+				resp.finish()
+			}()
+
+			wrapped.ServeHTTP(resp, req)
+		}()
+
+		select {
+		case <-resp.committed:
+			headers, cookies := encodeHeadersV2(resp.committedHeader)
+
+			return &events.LambdaFunctionURLStreamingResponse{
+				StatusCode: resp.statusCode,
+				Headers:    headers,
+				Body:       pr,
+				Cookies:    cookies,
+			}, nil
+		case err := <-failed:
+			return newStreamingServiceErrorResponse(o, req, err), nil
+		case <-ctx.Done():
+			slog.Error("handler did not start responding before the lambda deadline", "method", req.Method, "path", req.URL.Path)
+
+			// Unblock any later writes from the handler, nothing will ever read them.
+			pr.CloseWithError(ctx.Err())
+
+			return newStreamingServiceErrorResponse(o, req, errDeadlineExceeded()), nil
+		}
+	}
+}
+
+// newStreamingServiceErrorResponse builds a complete (non streamed) response holding err.
+func newStreamingServiceErrorResponse(
+	o *options,
+	req *http.Request,
+	err *serviceerror.ServiceError,
+) *events.LambdaFunctionURLStreamingResponse {
+	resp := newServiceErrorResponseWriter(o, req, err)
+	resp.Finalize()
+
+	headers, cookies := encodeHeadersV2(resp.Header())
+
+	return &events.LambdaFunctionURLStreamingResponse{
+		StatusCode: resp.StatusCode,
+		Headers:    headers,
+		Body:       strings.NewReader(resp.Body),
+		Cookies:    cookies,
+	}
+}
+
+// StreamingResponseWriter is a http.ResponseWriter and http.Flusher which streams the response through a Lambda
+// Function URL. As with net/http, changes to the headers after WriteHeader has been called have no effect.
+type StreamingResponseWriter struct {
+	header          http.Header
+	committedHeader http.Header
+	statusCode      int
+	committed       chan struct{}
+	pw              *io.PipeWriter
+	buf             *bufio.Writer
+	implicitStatus  bool
+	discarding      bool
+	errorFormat     handler.ErrorFormat
+	errorInstance   string
+}
+
+var (
+	_ http.Flusher           = (*StreamingResponseWriter)(nil)
+	_ handler.ErrorFormatter = (*StreamingResponseWriter)(nil)
+)
+
+func newStreamingResponseWriter(headers http.Header, pw *io.PipeWriter) *StreamingResponseWriter {
+	if headers == nil {
+		headers = http.Header{}
+	}
+
+	return &StreamingResponseWriter{
+		header:    headers.Clone(),
+		committed: make(chan struct{}),
+		pw:        pw,
+		buf:       bufio.NewWriter(pw),
+	}
+}
+
+// ErrorFormat implements handler.ErrorFormatter, reporting the error format the request asks for.
+func (w *StreamingResponseWriter) ErrorFormat() (handler.ErrorFormat, string) {
+	return w.errorFormat, w.errorInstance
+}
+
+func (w *StreamingResponseWriter) Header() http.Header {
+	return w.header
+}
+
+// WriteHeader sends the status and headers to the client. Later calls are logged and ignored, and if the status was
+// sent by WriteHeader rather than by Write or Flush they start a second response, which is discarded.
+func (w *StreamingResponseWriter) WriteHeader(statusCode int) {
+	if w.isCommitted() {
+		slog.Warn("ignoring second response written by handler", "status", w.statusCode, "ignored", statusCode)
+		if !w.implicitStatus {
+			w.discarding = true
+		}
+
+		return
+	}
+
+	w.commit(statusCode)
+}
+
+// commitOK sends a 200 status, as Write and Flush do when WriteHeader has not been called.
+func (w *StreamingResponseWriter) commitOK() {
+	if !w.isCommitted() {
+		w.commit(http.StatusOK)
+		w.implicitStatus = true
+	}
+}
+
+func (w *StreamingResponseWriter) commit(statusCode int) {
+	w.statusCode = statusCode
+	w.committedHeader = w.header.Clone()
+	close(w.committed)
+}
+
+// Write buffers body for streaming to the client, sending a 200 status first if WriteHeader has not been called.
+func (w *StreamingResponseWriter) Write(body []byte) (int, error) {
+	if w.discarding {
+		return len(body), nil
+	}
+
+	w.commitOK()
+
+	return w.buf.Write(body)
+}
+
+// Flush sends everything written so far to the client.
+func (w *StreamingResponseWriter) Flush() {
+	w.commitOK()
+
+	if err := w.buf.Flush(); err != nil {
+		slog.Error(err.Error())
+	}
+}
+
+// finish flushes any buffered body and ends the stream once the handler has returned.
+func (w *StreamingResponseWriter) finish() {
+	w.Flush()
+	w.pw.Close()
+}
+
+func (w *StreamingResponseWriter) isCommitted() bool {
+	select {
+	case <-w.committed:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,229 @@
+package aws
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamingSuite struct {
+	suite.Suite
+	opts *options
+	req  *events.LambdaFunctionURLRequest
+}
+
+func (s *StreamingSuite) SetupTest() {
+	s.opts = newOptions(
+		WithDefaultHeaders(http.Header{"Content-Type": {"text/csv"}}),
+	)
+	s.req = &events.LambdaFunctionURLRequest{
+		RawPath: "/export",
+		RequestContext: events.LambdaFunctionURLRequestContext{
+			HTTP: events.LambdaFunctionURLRequestContextHTTPDescription{
+				Method: http.MethodGet,
+			},
+		},
+	}
+}
+
+func (s *StreamingSuite) TestStreamsFlushedChunks() {
+	flushed := make(chan struct{})
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Set-Cookie", "export=1")
+		w.Write([]byte("a,b\n"))
+		w.(http.Flusher).Flush()
+
+		<-flushed
+		w.Write([]byte("c,d\n"))
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+	s.NoError(err)
+
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal(map[string]string{"Content-Type": "text/csv"}, res.Headers)
+	s.Equal([]string{"export=1"}, res.Cookies)
+
+	chunk := make([]byte, 4)
+	_, err = io.ReadFull(res.Body, chunk)
+	s.NoError(err)
+	s.Equal("a,b\n", string(chunk))
+
+	close(flushed)
+
+	rest, err := io.ReadAll(res.Body)
+	s.NoError(err)
+	s.Equal("c,d\n", string(rest))
+}
+
+func (s *StreamingSuite) TestContextOutlivesFirstFlush() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	flushed := make(chan struct{})
+	var ctxErr error
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a,b\n"))
+		w.(http.Flusher).Flush()
+
+		<-flushed
+		ctxErr = r.Context().Err()
+		w.Write([]byte("c,d\n"))
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(ctx, s.req)
+	s.NoError(err)
+
+	close(flushed)
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+	s.Equal("a,b\nc,d\n", string(body))
+	s.NoError(ctxErr)
+}
+
+func (s *StreamingSuite) TestHeadersAfterWriteHeaderAreIgnored() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		w.Header().Set("X-Late", "true")
+		w.WriteHeader(http.StatusTeapot)
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+	s.NoError(err)
+
+	s.Equal(http.StatusAccepted, res.StatusCode)
+	s.NotContains(res.Headers, "X-Late")
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+	s.Empty(body)
+}
+
+func (s *StreamingSuite) TestSecondResponseIgnored() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte("not found"))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("a,b\n"))
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+	s.NoError(err)
+
+	s.Equal(http.StatusNotFound, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+	s.Equal("not found", string(body))
+}
+
+func (s *StreamingSuite) TestWriteHeaderAfterImplicitStatus() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a,b\n"))
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte("c,d\n"))
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+	s.NoError(err)
+
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+	s.Equal("a,b\nc,d\n", string(body))
+}
+
+func (s *StreamingSuite) TestPanicBeforeResponse() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+	s.NoError(err)
+
+	s.Equal(http.StatusInternalServerError, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	s.NoError(err)
+	s.Equal(`{"error":{"id":"INTERNAL_SERVER_ERROR","code":"INTERNAL_SERVER_ERROR","message":"An internal error occurred"}}`, string(body))
+}
+
+func (s *StreamingSuite) TestPanicMidStream() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("a,b\n"))
+		w.(http.Flusher).Flush()
+		panic("boom")
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+	s.NoError(err)
+	s.Equal(http.StatusOK, res.StatusCode)
+
+	body, err := io.ReadAll(res.Body)
+	s.Error(err)
+	s.Equal("a,b\n", string(body))
+}
+
+func (s *StreamingSuite) TestErrorFormat() {
+	h := func(w http.ResponseWriter, r *http.Request) {
+		handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.NotFound("Export not found"))
+	}
+
+	problem := `{"type":"about:blank","title":"Not Found","status":404,"detail":"Export not found","instance":"/export","code":"NOT_FOUND"}`
+	serviceError := `{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"Export not found"}}`
+
+	for _, tc := range []struct {
+		format   handler.ErrorFormat
+		accept   string
+		expected string
+	}{
+		{format: handler.ServiceErrorFormat, expected: serviceError},
+		{format: handler.ServiceErrorFormat, accept: "application/problem+json", expected: problem},
+		{format: handler.ProblemFormat, expected: problem},
+		{format: handler.ProblemFormat, accept: "application/problem+json;q=0", expected: serviceError},
+	} {
+		s.opts.errorFormat = tc.format
+		s.req.Headers = map[string]string{"Accept": tc.accept}
+
+		res, err := getHandlerFunctionURLStreaming(h, s.opts)(context.Background(), s.req)
+		s.NoError(err)
+		s.Equal(http.StatusNotFound, res.StatusCode)
+
+		body, err := io.ReadAll(res.Body)
+		s.NoError(err)
+		s.JSONEq(tc.expected, string(body), tc.accept)
+	}
+}
+
+func (s *StreamingSuite) TestDeadline() {
+	s.opts.deadlineMargin = 10 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	release := make(chan struct{})
+	h := func(w http.ResponseWriter, r *http.Request) {
+		<-release
+		w.Write([]byte("too late"))
+	}
+
+	res, err := getHandlerFunctionURLStreaming(h, s.opts)(ctx, s.req)
+	s.NoError(err)
+	close(release)
+
+	s.Equal(http.StatusGatewayTimeout, res.StatusCode)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestStreamingSuite(t *testing.T) {
+	suite.Run(t, new(StreamingSuite))
+}
@@ -32,11 +32,13 @@ func FindHandler(
 		token := req.Header.Get("Authorization")
 		if err := connector.Authorize(token); err != nil {
 			resHander.BuildErrorResponse(w, err)
+			return
 		}
 
 		query, err := url.ParseQuery(req.URL.RawQuery)
 		if err != nil {
 			resHander.BuildErrorResponse(w, err)
+			return
 		}
 
 		var postcode string
@@ -44,11 +46,13 @@ func FindHandler(
 			postcode = query.Get("postcode")
 		} else {
 			resHander.BuildErrorResponse(w, errors.New("postcode required"))
+			return
 		}
 
 		addresses, err := connector.Find(postcode)
 		if err != nil {
 			resHander.BuildErrorResponse(w, err)
+			return
 		}
 
 		resHander.BuildResponse(w, http.StatusOK, addresses)
@@ -10,6 +10,7 @@ import (
 	"github.com/itsoneiota/lambda-handlers/v2/internal/mocks"
 	"github.com/itsoneiota/lambda-handlers/v2/pkg/aws"
 	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -73,7 +74,7 @@ func (s *FindHandlerSuite) TestHandler() {
 	expectAwsRes := &events.APIGatewayProxyResponse{
 		StatusCode:        200,
 		Headers:           map[string]string{"Content-Type": "application/json"},
-		MultiValueHeaders: map[string][]string(nil),
+		MultiValueHeaders: map[string][]string{"Content-Type": {"application/json"}},
 		Body:              "{\"success\":false}",
 		IsBase64Encoded:   false,
 	}
@@ -82,6 +83,20 @@ func (s *FindHandlerSuite) TestHandler() {
 	s.Equal(expectAwsRes, awsRes)
 }
 
+func (s *FindHandlerSuite) TestHandlerUnauthorized() {
+	c := new(mocks.Connector)
+	c.On("Authorize", s.token).Return(serviceerror.Unauthorized("Invalid token")).Times(1)
+
+	res := aws.NewResponseWriter(http.Header{})
+	FindHandler(handler.NewResponseHandler(), c)(res, s.req)
+
+	awsRes := aws.NewEvent(res)
+
+	s.Equal(http.StatusUnauthorized, awsRes.StatusCode)
+	s.JSONEq(`{"error":{"id":"UNAUTHORIZED","code":"UNAUTHORIZED","message":"Invalid token"}}`, awsRes.Body)
+	c.AssertNotCalled(s.T(), "Find", s.query)
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestFindHandlerSuite(t *testing.T) {
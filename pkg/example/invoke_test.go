@@ -0,0 +1,36 @@
+package example
+
+import (
+	"os"
+	"strings"
+
+	"github.com/itsoneiota/lambda-handlers/v2/internal/mocks"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/aws"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+)
+
+// A main replaying events against the FindHandler calls aws.InvokeMain with the handler wired to a real connector,
+// here the arguments and event are given to aws.InvokeCLI directly.
+func ExampleFindHandler_invoke() {
+	c := new(mocks.Connector)
+	c.On("Authorize", "authToken").Return(nil)
+	c.On("Find", "AB1 2CD").Return(ExampleModel{Success: true}, nil)
+
+	event := `{"path": "/find", "httpMethod": "GET", "queryStringParameters": {"postcode": "AB1 2CD"}}`
+
+	aws.InvokeCLI(
+		[]string{"-header", "Authorization: authToken"},
+		strings.NewReader(event),
+		os.Stdout,
+		os.Stderr,
+		FindHandler(handler.NewResponseHandler(), c),
+	)
+
+	// Output:
+	// {
+	//   "statusCode": 200,
+	//   "headers": {},
+	//   "multiValueHeaders": {},
+	//   "body": "{\"success\":true}"
+	// }
+}
@@ -0,0 +1,93 @@
+package handler
+
+import (
+	"log/slog"
+	"net/http"
+)
+
+// ErrorHandlerFunc is a handler which returns its error rather than writing the error response itself.
+type ErrorHandlerFunc func(w http.ResponseWriter, req *http.Request) error
+
+// ResultHandlerFunc is a handler which returns the model to respond with rather than writing the response itself.
+type ResultHandlerFunc func(req *http.Request) (any, error)
+
+// HandleError turns h into a http.HandlerFunc which writes exactly one response.
+// A returned error is written with ResponseHandler.BuildErrorResponse, unless h has already started a response,
+// in which case it is logged. A second response written by h is logged and discarded.
+func HandleError(h ErrorHandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		ow := &onceWriter{writerWrapper: writerWrapper{w}}
+
+		err := h(ow, req)
+		if err == nil {
+			return
+		}
+
+		if ow.wroteHeader {
+			slog.Error("handler returned an error after writing a response", "error", err.Error(), "status", ow.status)
+			return
+		}
+
+		NewResponseHandler().BuildErrorResponse(ow, err)
+	}
+}
+
+// HandleResult turns h into a http.HandlerFunc which writes the returned model as a 200 JSON response,
+// or the returned error with ResponseHandler.BuildErrorResponse.
+func HandleResult(h ResultHandlerFunc) http.HandlerFunc {
+	return HandleError(func(w http.ResponseWriter, req *http.Request) error {
+		result, err := h(req)
+		if err != nil {
+			return err
+		}
+
+		return NewResponseHandler().BuildResponse(w, http.StatusOK, result)
+	})
+}
+
+// WriteOnce is middleware which lets a single response through, as the aws response writers do.
+// A second response written by the wrapped handler is logged and discarded.
+func WriteOnce(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		next.ServeHTTP(&onceWriter{writerWrapper: writerWrapper{w}}, req)
+	})
+}
+
+// onceWriter lets a single response through. A second WriteHeader is logged and ignored, and when the first status
+// was also written by WriteHeader, rather than implied by Write, any following writes are ignored too.
+type onceWriter struct {
+	writerWrapper
+	status         int
+	wroteHeader    bool
+	implicitStatus bool
+	discarding     bool
+}
+
+func (w *onceWriter) WriteHeader(statusCode int) {
+	if w.wroteHeader {
+		slog.Warn("ignoring second response written by handler", "status", w.status, "ignored", statusCode)
+		if !w.implicitStatus {
+			w.discarding = true
+		}
+
+		return
+	}
+
+	w.wroteHeader = true
+	w.status = statusCode
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *onceWriter) Write(body []byte) (int, error) {
+	if w.discarding {
+		return len(body), nil
+	}
+
+	if !w.wroteHeader {
+		w.wroteHeader = true
+		w.implicitStatus = true
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(body)
+}
@@ -0,0 +1,101 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type AdapterSuite struct {
+	suite.Suite
+	req *http.Request
+}
+
+func (s *AdapterSuite) SetupTest() {
+	s.req = httptest.NewRequest(http.MethodGet, "/", nil)
+}
+
+func (s *AdapterSuite) TestHandleError() {
+	h := HandleError(func(w http.ResponseWriter, req *http.Request) error {
+		return serviceerror.NotFound("Product not found")
+	})
+
+	w := httptest.NewRecorder()
+	h(w, s.req)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.JSONEq(`{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"Product not found"}}`, w.Body.String())
+}
+
+func (s *AdapterSuite) TestHandleErrorAfterResponse() {
+	h := HandleError(func(w http.ResponseWriter, req *http.Request) error {
+		NewResponseHandler().BuildResponse(w, http.StatusAccepted, Model{Success: true})
+		return errors.New("failed after responding")
+	})
+
+	w := httptest.NewRecorder()
+	h(w, s.req)
+
+	s.Equal(http.StatusAccepted, w.Code)
+	s.Equal(`{"success":true}`, w.Body.String())
+}
+
+func (s *AdapterSuite) TestHandleErrorSecondResponseIgnored() {
+	h := HandleError(func(w http.ResponseWriter, req *http.Request) error {
+		rh := NewResponseHandler()
+		rh.BuildErrorResponse(w, serviceerror.Unauthorized("Invalid token"))
+		rh.BuildResponse(w, http.StatusOK, Model{Success: true})
+
+		return nil
+	})
+
+	w := httptest.NewRecorder()
+	h(w, s.req)
+
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.JSONEq(`{"error":{"id":"UNAUTHORIZED","code":"UNAUTHORIZED","message":"Invalid token"}}`, w.Body.String())
+}
+
+func (s *AdapterSuite) TestWriteOnceWriteHeaderAfterImplicitStatus() {
+	h := WriteOnce(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte(`{"a":`))
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`1}`))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, s.req)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(`{"a":1}`, w.Body.String())
+}
+
+func (s *AdapterSuite) TestHandleResult() {
+	h := HandleResult(func(req *http.Request) (any, error) {
+		if req.URL.Query().Get("fail") != "" {
+			return nil, serviceerror.BadRequest("Invalid query")
+		}
+
+		return Model{Success: true}, nil
+	})
+
+	w := httptest.NewRecorder()
+	h(w, s.req)
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(`{"success":true}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?fail=true", nil))
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.JSONEq(`{"error":{"id":"BAD_REQUEST","code":"BAD_REQUEST","message":"Invalid query"}}`, w.Body.String())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestAdapterSuite(t *testing.T) {
+	suite.Run(t, new(AdapterSuite))
+}
@@ -0,0 +1,317 @@
+package handler
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strconv"
+
+	"github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+var errUnsupportedType = errors.New("unsupported field type")
+
+// invalidJSONMessage is the message of the BAD_REQUEST service error returned for a body which cannot be decoded.
+const invalidJSONMessage = "Invalid JSON body"
+
+// bindSource is a part of the request fields can be bound from, selected with the struct tag of the same name.
+type bindSource struct {
+	tag    string
+	label  string
+	values func(req *http.Request, name string) []string
+}
+
+var bindSources = []bindSource{
+	{
+		tag:   "path",
+		label: "path parameter",
+		values: func(req *http.Request, name string) []string {
+			if v, ok := mux.Vars(req)[name]; ok {
+				return []string{v}
+			}
+
+			return nil
+		},
+	},
+	{
+		tag:   "query",
+		label: "query parameter",
+		values: func(req *http.Request, name string) []string {
+			return req.URL.Query()[name]
+		},
+	},
+	{
+		tag:   "header",
+		label: "header",
+		values: func(req *http.Request, name string) []string {
+			return req.Header.Values(name)
+		},
+	},
+}
+
+// Bind populates v, a pointer to a struct, from req. A JSON body, sent as application/json or without a Content-Type,
+// is decoded into v first, then fields tagged path, query or header are set from the path parameters (see mux.Vars),
+// query string and headers. Tagged fields are never set from the body:
+//
+//	type FindRequest struct {
+//		ID       string   `path:"id"`
+//		Page     int      `query:"page"`
+//		Tags     []string `query:"tag"`
+//		Token    string   `header:"Authorization"`
+//		Postcode string   `json:"postcode"`
+//	}
+//
+// Tagged fields may be strings, booleans, numbers, encoding.TextUnmarshaler implementations, or pointers and slices
+// of these. A value which cannot be bound is returned as a BAD_REQUEST service error, listing a FieldError with the
+// rule type for a body field of the wrong JSON type.
+// Once bound, v is checked with Validate.
+func Bind(req *http.Request, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("bind target must be a non-nil pointer, got %T", v)
+	}
+
+	if err := bindBody(req, v); err != nil {
+		return err
+	}
+
+	if rv.Elem().Kind() == reflect.Struct {
+		if err := bindFields(req, rv.Elem()); err != nil {
+			return err
+		}
+	}
+
+	return Validate(v)
+}
+
+// bindBody decodes a JSON body into v, the body is restored afterwards so it can be read again.
+// Other bodies, such as forms, are left for the handler to read.
+func bindBody(req *http.Request, v any) error {
+	if req.Body == nil || req.Body == http.NoBody || !isJSON(req) {
+		return nil
+	}
+
+	body, err := io.ReadAll(req.Body)
+	req.Body.Close()
+	req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return err
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+
+	if err := decodeBody(body, v); err != nil {
+		var typeErr *json.UnmarshalTypeError
+		if errors.As(err, &typeErr) && typeErr.Field != "" {
+			return serviceerror.BadRequestWithFields(invalidJSONMessage, []serviceerror.FieldError{{
+				Path:    typeErr.Field,
+				Rule:    "type",
+				Message: fmt.Sprintf("%s must be %s", typeErr.Field, jsonTypeName(typeErr.Type)),
+			}}).WithCause(err)
+		}
+
+		return serviceerror.BadRequest(invalidJSONMessage).WithCause(err)
+	}
+
+	return nil
+}
+
+// jsonTypeName describes the JSON value expected for t.
+func jsonTypeName(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return "an integer"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "a non-negative integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	default:
+		return "an object"
+	}
+}
+
+// decodeBody unmarshals body into v, leaving any fields bound from elsewhere in the request as they were.
+func decodeBody(body []byte, v any) error {
+	rv := reflect.ValueOf(v).Elem()
+	if rv.Kind() != reflect.Struct {
+		return json.Unmarshal(body, v)
+	}
+
+	decoded := reflect.New(rv.Type())
+	decoded.Elem().Set(rv)
+	if err := json.Unmarshal(body, decoded.Interface()); err != nil {
+		return err
+	}
+
+	restoreBoundFields(decoded.Elem(), rv)
+	rv.Set(decoded.Elem())
+
+	return nil
+}
+
+// restoreBoundFields copies the fields tagged path, query or header from src into dst.
+func restoreBoundFields(dst, src reflect.Value) {
+	rt := dst.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		if field.Anonymous && field.Type.Kind() == reflect.Struct {
+			restoreBoundFields(dst.Field(i), src.Field(i))
+			continue
+		}
+
+		for _, source := range bindSources {
+			if field.Tag.Get(source.tag) != "" {
+				dst.Field(i).Set(src.Field(i))
+				break
+			}
+		}
+	}
+}
+
+// isJSON reports whether the body of req is JSON, assuming so when no Content-Type is given.
+func isJSON(req *http.Request) bool {
+	contentType := req.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+
+	return err == nil && mediaType == "application/json"
+}
+
+func bindFields(req *http.Request, rv reflect.Value) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && fv.Kind() == reflect.Struct {
+			if err := bindFields(req, fv); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		for _, source := range bindSources {
+			name := field.Tag.Get(source.tag)
+			if name == "" {
+				continue
+			}
+
+			values := source.values(req, name)
+			if len(values) == 0 {
+				continue
+			}
+
+			if err := setField(fv, values); err != nil {
+				if errors.Is(err, errUnsupportedType) {
+					return fmt.Errorf("cannot bind %s %q into field %s: %w", source.label, name, field.Name, err)
+				}
+
+				return serviceerror.BadRequest(fmt.Sprintf("Invalid %s %q: %s", source.label, name, err))
+			}
+		}
+	}
+
+	return nil
+}
+
+func setField(fv reflect.Value, values []string) error {
+	if fv.Kind() == reflect.Pointer {
+		ptr := reflect.New(fv.Type().Elem())
+		if err := setField(ptr.Elem(), values); err != nil {
+			return err
+		}
+
+		fv.Set(ptr)
+
+		return nil
+	}
+
+	if u, ok := fv.Addr().Interface().(encoding.TextUnmarshaler); ok {
+		if err := u.UnmarshalText([]byte(values[0])); err != nil {
+			return fmt.Errorf("%q is not valid: %s", values[0], err)
+		}
+
+		return nil
+	}
+
+	if fv.Kind() == reflect.Slice {
+		slice := reflect.MakeSlice(fv.Type(), len(values), len(values))
+		for i, value := range values {
+			if err := setValue(slice.Index(i), value); err != nil {
+				return err
+			}
+		}
+
+		fv.Set(slice)
+
+		return nil
+	}
+
+	return setValue(fv, values[0])
+}
+
+func setValue(fv reflect.Value, value string) error {
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("%q is not a valid boolean", value)
+		}
+
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a valid integer", value)
+		}
+
+		fv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(value, 10, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a valid unsigned integer", value)
+		}
+
+		fv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return fmt.Errorf("%q is not a valid number", value)
+		}
+
+		fv.SetFloat(n)
+	default:
+		return fmt.Errorf("%w %s", errUnsupportedType, fv.Type())
+	}
+
+	return nil
+}
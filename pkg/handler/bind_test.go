@@ -0,0 +1,179 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type Paging struct {
+	Page  int  `query:"page"`
+	Limit *int `query:"limit"`
+}
+
+type bindRequest struct {
+	Paging
+	ID       string    `path:"id"`
+	Tags     []string  `query:"tag"`
+	Active   bool      `query:"active"`
+	Price    float64   `query:"price"`
+	Since    time.Time `query:"since"`
+	Token    string    `header:"Authorization"`
+	Postcode string    `json:"postcode"`
+	Count    uint      `json:"count"`
+}
+
+type BindSuite struct {
+	suite.Suite
+}
+
+func (s *BindSuite) request(target, body string) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, target, strings.NewReader(body))
+	req.Header.Set("Authorization", "authToken")
+
+	return mux.SetURLVars(req, map[string]string{"id": "ABC123"})
+}
+
+func (s *BindSuite) TestBind() {
+	req := s.request(
+		"/products/ABC123?page=2&limit=5&tag=a&tag=b&active=true&price=9.99&since=2024-01-02T03:04:05Z",
+		`{"postcode":"AB1 2CD","count":3}`,
+	)
+
+	var got bindRequest
+	s.NoError(Bind(req, &got))
+
+	limit := 5
+	s.Equal(bindRequest{
+		Paging:   Paging{Page: 2, Limit: &limit},
+		ID:       "ABC123",
+		Tags:     []string{"a", "b"},
+		Active:   true,
+		Price:    9.99,
+		Since:    time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Token:    "authToken",
+		Postcode: "AB1 2CD",
+		Count:    3,
+	}, got)
+
+	body, err := io.ReadAll(req.Body)
+	s.NoError(err)
+	s.Equal(`{"postcode":"AB1 2CD","count":3}`, string(body))
+}
+
+func (s *BindSuite) TestBindMissingValues() {
+	var got bindRequest
+	s.NoError(Bind(httptest.NewRequest(http.MethodGet, "/", nil), &got))
+
+	s.Equal(bindRequest{}, got)
+}
+
+func (s *BindSuite) TestBindInvalidValues() {
+	for target, message := range map[string]string{
+		"/?page=two":     `Invalid query parameter "page": "two" is not a valid integer`,
+		"/?active=maybe": `Invalid query parameter "active": "maybe" is not a valid boolean`,
+		"/?price=free":   `Invalid query parameter "price": "free" is not a valid number`,
+		"/?limit=-":      `Invalid query parameter "limit": "-" is not a valid integer`,
+	} {
+		var got bindRequest
+		err := Bind(s.request(target, ""), &got)
+
+		var se *serviceerror.ServiceError
+		s.ErrorAs(err, &se)
+		s.Equal(http.StatusBadRequest, se.StatusCode())
+		s.Equal(message, se.Error(), target)
+	}
+}
+
+func (s *BindSuite) TestBindInvalidJSON() {
+	var got bindRequest
+	err := Bind(s.request("/", `{"postcode":`), &got)
+
+	var se *serviceerror.ServiceError
+	s.ErrorAs(err, &se)
+	s.Equal(http.StatusBadRequest, se.StatusCode())
+	s.Equal("Invalid JSON body", se.Error())
+	s.Empty(se.Err.Fields)
+}
+
+func (s *BindSuite) TestBindWrongJSONType() {
+	for body, field := range map[string]serviceerror.FieldError{
+		`{"count":-1}`:         {Path: "count", Rule: "type", Message: "count must be a non-negative integer"},
+		`{"postcode":1}`:       {Path: "postcode", Rule: "type", Message: "postcode must be a string"},
+		`{"nested":{"n":"x"}}`: {Path: "nested.n", Rule: "type", Message: "nested.n must be an integer"},
+	} {
+		var got struct {
+			Count    uint   `json:"count"`
+			Postcode string `json:"postcode"`
+			Nested   struct {
+				N int `json:"n"`
+			} `json:"nested"`
+		}
+		err := Bind(s.request("/", body), &got)
+
+		var se *serviceerror.ServiceError
+		s.ErrorAs(err, &se)
+		s.Equal(http.StatusBadRequest, se.StatusCode())
+		s.Equal("Invalid JSON body", se.Error())
+		s.Equal([]serviceerror.FieldError{field}, se.Err.Fields, body)
+	}
+}
+
+func (s *BindSuite) TestBindIgnoresBoundFieldsInBody() {
+	req := httptest.NewRequest(http.MethodPost, "/?page=3", strings.NewReader(`{"Token":"injected","ID":"other","Page":9,"postcode":"AB1 2CD"}`))
+
+	got := bindRequest{Paging: Paging{Page: 1}}
+	s.NoError(Bind(req, &got))
+
+	s.Equal(bindRequest{
+		Paging:   Paging{Page: 3},
+		Postcode: "AB1 2CD",
+	}, got)
+
+	got = bindRequest{Paging: Paging{Page: 1}}
+	s.NoError(Bind(httptest.NewRequest(http.MethodPost, "/", strings.NewReader(`{"Page":9}`)), &got))
+	s.Equal(1, got.Page)
+}
+
+func (s *BindSuite) TestBindContentType() {
+	req := s.request("/", `postcode=AB1+2CD`)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	var got bindRequest
+	s.NoError(Bind(req, &got))
+	s.Empty(got.Postcode)
+	s.Equal("AB1 2CD", req.FormValue("postcode"))
+
+	req = s.request("/", `{"postcode":"AB1 2CD"}`)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	got = bindRequest{}
+	s.NoError(Bind(req, &got))
+	s.Equal("AB1 2CD", got.Postcode)
+}
+
+func (s *BindSuite) TestBindUnsupportedType() {
+	var got struct {
+		Filter map[string]string `query:"filter"`
+	}
+
+	err := Bind(httptest.NewRequest(http.MethodGet, "/?filter=a", nil), &got)
+	s.ErrorIs(err, errUnsupportedType)
+}
+
+func (s *BindSuite) TestBindNonPointer() {
+	s.Error(Bind(httptest.NewRequest(http.MethodGet, "/", nil), bindRequest{}))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestBindSuite(t *testing.T) {
+	suite.Run(t, new(BindSuite))
+}
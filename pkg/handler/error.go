@@ -1,28 +1,15 @@
 package handler
 
-// ServiceError - represents the service error
-type ServiceError struct {
-	Err Error `json:"error"`
-}
+import "github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+
+// ServiceError - represents the service error.
+// It is the serviceerror.ServiceError model, so errors built with either package behave the same.
+type ServiceError = serviceerror.ServiceError
 
 // Error holds the error contents of the service error
-type Error struct {
-	ID      string `json:"id"`
-	Code    string `json:"code"`
-	Message string `json:"message"`
-}
+type Error = serviceerror.Error
 
+// NewServiceError creates a service error whose HTTP status is mapped from code, see serviceerror.Register
 func NewServiceError(id, code, message string) *ServiceError {
-	return &ServiceError{
-		Err: Error{
-			ID:      id,
-			Code:    code,
-			Message: message,
-		},
-	}
-}
-
-// Error returns the error message
-func (se *ServiceError) Error() string {
-	return se.Err.Message
+	return serviceerror.NewServiceError(id, code, message)
 }
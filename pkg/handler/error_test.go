@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorSuite struct {
+	suite.Suite
+}
+
+func (s *ErrorSuite) TestConstructorsAreCompatible() {
+	for code, status := range map[string]int{
+		serviceerror.CodeNotFound:     http.StatusNotFound,
+		serviceerror.CodeBadRequest:   http.StatusBadRequest,
+		serviceerror.CodeUnauthorized: http.StatusUnauthorized,
+		serviceerror.CodeConflict:     http.StatusConflict,
+		"SOMETHING_ELSE":              http.StatusInternalServerError,
+	} {
+		fromHandler := NewServiceError(code, code, "message")
+		fromServiceError := serviceerror.NewServiceError(code, code, "message")
+
+		s.Equal(fromServiceError, fromHandler, code)
+		s.Equal(status, fromHandler.StatusCode(), code)
+		s.Equal(code, fromHandler.Code(), code)
+		s.True(errors.Is(fromHandler, fromServiceError), code)
+
+		handlerJSON, err := json.Marshal(fromHandler)
+		s.NoError(err)
+		serviceErrorJSON, err := json.Marshal(fromServiceError)
+		s.NoError(err)
+		s.JSONEq(string(serviceErrorJSON), string(handlerJSON), code)
+
+		for _, e := range []error{fromHandler, fromServiceError} {
+			w := httptest.NewRecorder()
+			NewResponseHandler().BuildErrorResponse(w, e)
+
+			s.Equal(status, w.Code, code)
+			s.JSONEq(string(serviceErrorJSON), w.Body.String(), code)
+		}
+	}
+}
+
+func (s *ErrorSuite) TestStructLiteral() {
+	var err error = &ServiceError{
+		Err: Error{
+			ID:      "NOT_FOUND",
+			Code:    serviceerror.CodeNotFound,
+			Message: "Product not found",
+		},
+	}
+
+	var se *serviceerror.ServiceError
+	s.True(errors.As(err, &se))
+	s.Equal(http.StatusNotFound, se.StatusCode())
+	s.Equal("Product not found", err.Error())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestErrorSuite(t *testing.T) {
+	suite.Run(t, new(ErrorSuite))
+}
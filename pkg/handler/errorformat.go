@@ -0,0 +1,124 @@
+package handler
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+// ErrorFormat selects how error responses are rendered.
+type ErrorFormat int
+
+const (
+	// ServiceErrorFormat renders errors as {"error":{"id","code","message"}}.
+	ServiceErrorFormat ErrorFormat = iota
+	// ProblemFormat renders errors as RFC 7807 application/problem+json.
+	ProblemFormat
+)
+
+// ErrorFormatter is implemented by response writers which know how the client wants errors rendered,
+// along with the instance (the request path) reported in a problem.
+type ErrorFormatter interface {
+	ErrorFormat() (ErrorFormat, string)
+}
+
+// ErrorFormatFor returns the error format requested by the Accept header of req: ProblemFormat if it accepts
+// application/problem+json, ServiceErrorFormat if it refuses it with q=0, otherwise def.
+func ErrorFormatFor(req *http.Request, def ErrorFormat) ErrorFormat {
+	for _, accept := range req.Header.Values("Accept") {
+		for _, mediaRange := range strings.Split(accept, ",") {
+			mediaType, params, err := mime.ParseMediaType(mediaRange)
+			if err != nil || mediaType != serviceerror.ProblemContentType {
+				continue
+			}
+
+			if q, err := strconv.ParseFloat(params["q"], 64); err == nil && q == 0 {
+				return ServiceErrorFormat
+			}
+
+			return ProblemFormat
+		}
+	}
+
+	return def
+}
+
+// NegotiateErrorFormat is middleware which lets BuildErrorResponse render errors in the format chosen by ErrorFormatFor,
+// service errors unless the request asks for problem+json.
+func NegotiateErrorFormat(next http.Handler) http.Handler {
+	return NegotiateErrorFormatWith(ServiceErrorFormat)(next)
+}
+
+// NegotiateErrorFormatWith is NegotiateErrorFormat rendering errors in def unless the request asks otherwise.
+func NegotiateErrorFormatWith(def ErrorFormat) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			next.ServeHTTP(&formatWriter{
+				writerWrapper: writerWrapper{w},
+				format:        ErrorFormatFor(req, def),
+				instance:      req.URL.Path,
+			}, req)
+		})
+	}
+}
+
+// WriteError writes err in the error format of w, without logging it.
+func WriteError(w http.ResponseWriter, err *serviceerror.ServiceError) error {
+	return writeError(w, err.StatusCode(), err, http.Header{})
+}
+
+// writeError writes err, which must marshal to the service error shape, with the given status.
+func writeError(w http.ResponseWriter, statusCode int, err error, headers http.Header) error {
+	var body interface{} = err
+	if format, instance := errorFormat(w); format == ProblemFormat {
+		body = problem(statusCode, err, instance)
+		w.Header().Set("Content-Type", serviceerror.ProblemContentType)
+	}
+
+	return NewResponseHandler().BuildResponseWithHeader(w, statusCode, body, headers)
+}
+
+// errorFormat returns the error format of w, looking through any wrapping writers.
+func errorFormat(w http.ResponseWriter) (ErrorFormat, string) {
+	for {
+		if f, ok := w.(ErrorFormatter); ok {
+			return f.ErrorFormat()
+		}
+
+		u, ok := w.(interface{ Unwrap() http.ResponseWriter })
+		if !ok {
+			return ServiceErrorFormat, ""
+		}
+
+		w = u.Unwrap()
+	}
+}
+
+func problem(statusCode int, err error, instance string) *serviceerror.Problem {
+	var se *serviceerror.ServiceError
+	if errors.As(err, &se) {
+		return se.Problem(instance)
+	}
+
+	var coded interface{ Code() string }
+	if errors.As(err, &coded) {
+		return serviceerror.NewProblem(statusCode, coded.Code(), err.Error(), instance)
+	}
+
+	return serviceerror.NewProblem(statusCode, "", err.Error(), instance)
+}
+
+// formatWriter carries the error format negotiated for a request.
+type formatWriter struct {
+	writerWrapper
+	format   ErrorFormat
+	instance string
+}
+
+func (w *formatWriter) ErrorFormat() (ErrorFormat, string) {
+	return w.format, w.instance
+}
@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type ErrorFormatSuite struct {
+	suite.Suite
+}
+
+func (s *ErrorFormatSuite) request(accept string) *http.Request {
+	req := httptest.NewRequest(http.MethodGet, "/products/ABC123", nil)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+
+	return req
+}
+
+func (s *ErrorFormatSuite) serve(req *http.Request, err error) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	NegotiateErrorFormat(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		NewResponseHandler().BuildErrorResponse(w, err)
+	})).ServeHTTP(w, req)
+
+	return w
+}
+
+func (s *ErrorFormatSuite) TestErrorFormatFor() {
+	s.Equal(ServiceErrorFormat, ErrorFormatFor(s.request(""), ServiceErrorFormat))
+	s.Equal(ServiceErrorFormat, ErrorFormatFor(s.request("application/json"), ServiceErrorFormat))
+	s.Equal(ProblemFormat, ErrorFormatFor(s.request("application/problem+json"), ServiceErrorFormat))
+	s.Equal(ProblemFormat, ErrorFormatFor(s.request("application/json;q=0.9, application/problem+json"), ServiceErrorFormat))
+	s.Equal(ServiceErrorFormat, ErrorFormatFor(s.request("application/problem+json;q=0"), ServiceErrorFormat))
+
+	s.Equal(ProblemFormat, ErrorFormatFor(s.request("application/json"), ProblemFormat))
+	s.Equal(ServiceErrorFormat, ErrorFormatFor(s.request("application/problem+json; q=0.0"), ProblemFormat))
+}
+
+func (s *ErrorFormatSuite) TestServiceErrorFormatByDefault() {
+	w := s.serve(s.request("application/json"), serviceerror.NotFound("Product not found"))
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.JSONEq(`{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"Product not found"}}`, w.Body.String())
+}
+
+func (s *ErrorFormatSuite) TestProblemFormat() {
+	w := s.serve(s.request("application/problem+json"), serviceerror.NotFound("Product not found"))
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Equal(serviceerror.ProblemContentType, w.Header().Get("Content-Type"))
+	s.JSONEq(`{
+		"type":"about:blank",
+		"title":"Not Found",
+		"status":404,
+		"detail":"Product not found",
+		"instance":"/products/ABC123",
+		"code":"NOT_FOUND"
+	}`, w.Body.String())
+}
+
+func (s *ErrorFormatSuite) TestProblemFormatUnknownError() {
+	w := s.serve(s.request("application/problem+json"), errors.New("connection refused"))
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.JSONEq(`{
+		"type":"about:blank",
+		"title":"Internal Server Error",
+		"status":500,
+		"detail":"An unknown error occurred",
+		"instance":"/products/ABC123",
+		"code":"UNKNOWN_ERROR"
+	}`, w.Body.String())
+}
+
+func (s *ErrorFormatSuite) TestProblemFormatByDefault() {
+	w := httptest.NewRecorder()
+	NegotiateErrorFormatWith(ProblemFormat)(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		NewResponseHandler().BuildErrorResponse(w, serviceerror.Conflict("Already exists"))
+	})).ServeHTTP(w, s.request(""))
+
+	s.Equal(http.StatusConflict, w.Code)
+	s.JSONEq(`{"type":"about:blank","title":"Conflict","status":409,"detail":"Already exists","instance":"/products/ABC123","code":"CONFLICT"}`, w.Body.String())
+}
+
+func (s *ErrorFormatSuite) TestProblemFormatThroughWrappingWriters() {
+	w := httptest.NewRecorder()
+	NegotiateErrorFormat(HandleError(func(w http.ResponseWriter, req *http.Request) error {
+		return serviceerror.Forbidden("Not allowed")
+	})).ServeHTTP(w, s.request("application/problem+json"))
+
+	s.Equal(http.StatusForbidden, w.Code)
+	s.Equal(serviceerror.ProblemContentType, w.Header().Get("Content-Type"))
+	s.Contains(w.Body.String(), `"detail":"Not allowed"`)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestErrorFormatSuite(t *testing.T) {
+	suite.Run(t, new(ErrorFormatSuite))
+}
@@ -4,6 +4,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/url"
+	"time"
 )
 
 // Generic Request object which is used in every handler
@@ -40,4 +41,18 @@ type BeforeHandlerHook func(res http.ResponseWriter, req *http.Request) bool
 // A Callback function can be passed in when building a handler and is passed the raw API Gateway Request struct
 type AfterHandlerHook func(res http.ResponseWriter)
 
+// ResponseInfo describes a response once the handler has finished writing it.
+type ResponseInfo struct {
+	// Status is the final status code, 200 if the handler never set one.
+	Status int
+	// Body is everything written to the response, before any encoding applied by the adapter.
+	Body []byte
+	// Elapsed is the time taken to produce the response.
+	Elapsed time.Duration
+}
+
+// ResponseHook is a callback function called after every response, whatever its status.
+// Headers set on res are still sent with the response, which makes it suitable for audit logging, metrics and CORS headers.
+type ResponseHook func(res http.ResponseWriter, req *http.Request, info ResponseInfo)
+
 type HandlerFunc = func(res http.ResponseWriter, req Requester) error
@@ -0,0 +1,175 @@
+package handler
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+)
+
+// Middleware wraps a http.Handler with behaviour run before and/or after it.
+// It is the extension point shared by the aws adapters and the mux router.
+type Middleware func(http.Handler) http.Handler
+
+// writerWrapper is embedded by the response writers which wrap another. It passes Flush through so streaming handlers
+// keep working behind them, and Unwrap lets http.ResponseController reach the wrapped writer.
+type writerWrapper struct {
+	http.ResponseWriter
+}
+
+func (w writerWrapper) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+func (w writerWrapper) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// Chain composes middleware into one, the first given being the outermost.
+func Chain(mw ...Middleware) Middleware {
+	return func(h http.Handler) http.Handler {
+		for i := len(mw) - 1; i >= 0; i-- {
+			if mw[i] != nil {
+				h = mw[i](h)
+			}
+		}
+
+		return h
+	}
+}
+
+// BeforeHookMiddleware expresses a BeforeHandlerHook as middleware, the wrapped handler only runs if the hook returns true.
+func BeforeHookMiddleware(hook BeforeHandlerHook) Middleware {
+	return func(next http.Handler) http.Handler {
+		if hook == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			if hook(w, req) {
+				next.ServeHTTP(w, req)
+			}
+		})
+	}
+}
+
+// AfterHookMiddleware expresses an AfterHandlerHook as middleware, the hook runs once the wrapped handler has written a 2xx status.
+// The aws response writers hold the response until the handler returns, so there the hook may still change headers.
+// A net/http response writer, as used with mux, has already sent them; use ResponseHookMiddleware to set headers there.
+func AfterHookMiddleware(hook AfterHandlerHook) Middleware {
+	return func(next http.Handler) http.Handler {
+		if hook == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			sw := &statusWriter{writerWrapper: writerWrapper{w}}
+			next.ServeHTTP(sw, req)
+
+			if sw.status >= http.StatusOK && sw.status < http.StatusMultipleChoices {
+				hook(w)
+			}
+		})
+	}
+}
+
+// statusWriter records the status written through it.
+type statusWriter struct {
+	writerWrapper
+	status int
+}
+
+func (w *statusWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (w *statusWriter) Write(body []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	return w.ResponseWriter.Write(body)
+}
+
+// ResponseHookMiddleware expresses a ResponseHook as middleware. The response is held back until the hook has run,
+// so headers it sets are sent to the client. If the handler flushes, the response is sent at that point and the hook
+// still runs once the handler returns, but can no longer change the headers.
+func ResponseHookMiddleware(hook ResponseHook) Middleware {
+	return func(next http.Handler) http.Handler {
+		if hook == nil {
+			return next
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			start := time.Now()
+			bw := &bufferedWriter{writerWrapper: writerWrapper{w}}
+			next.ServeHTTP(bw, req)
+
+			hook(w, req, ResponseInfo{
+				Status:  bw.statusCode(),
+				Body:    bw.body.Bytes(),
+				Elapsed: time.Since(start),
+			})
+
+			bw.commit()
+		})
+	}
+}
+
+// bufferedWriter holds back the status and body written through it until commit is called.
+type bufferedWriter struct {
+	writerWrapper
+	status    int
+	body      bytes.Buffer
+	committed bool
+}
+
+func (w *bufferedWriter) WriteHeader(statusCode int) {
+	if w.status == 0 {
+		w.status = statusCode
+	}
+}
+
+func (w *bufferedWriter) Write(body []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	w.body.Write(body)
+	if w.committed {
+		return w.ResponseWriter.Write(body)
+	}
+
+	return len(body), nil
+}
+
+// Flush sends everything written so far, after which writes pass straight through.
+func (w *bufferedWriter) Flush() {
+	w.commit()
+	w.writerWrapper.Flush()
+}
+
+func (w *bufferedWriter) statusCode() int {
+	if w.status == 0 {
+		return http.StatusOK
+	}
+
+	return w.status
+}
+
+func (w *bufferedWriter) commit() {
+	if w.committed {
+		return
+	}
+
+	w.committed = true
+	w.ResponseWriter.WriteHeader(w.statusCode())
+	if w.body.Len() > 0 {
+		w.ResponseWriter.Write(w.body.Bytes())
+	}
+}
@@ -0,0 +1,150 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type MiddlewareSuite struct {
+	suite.Suite
+	calls []string
+}
+
+func (s *MiddlewareSuite) SetupTest() {
+	s.calls = nil
+}
+
+func (s *MiddlewareSuite) record(name string) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			s.calls = append(s.calls, name)
+			next.ServeHTTP(w, req)
+		})
+	}
+}
+
+func (s *MiddlewareSuite) handler(status int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.calls = append(s.calls, "handler")
+		w.WriteHeader(status)
+	})
+}
+
+func (s *MiddlewareSuite) TestChainOrder() {
+	h := Chain(s.record("first"), nil, s.record("second"))(s.handler(http.StatusOK))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal([]string{"first", "second", "handler"}, s.calls)
+}
+
+func (s *MiddlewareSuite) TestBeforeHookMiddleware() {
+	before := BeforeHookMiddleware(func(res http.ResponseWriter, req *http.Request) bool {
+		s.calls = append(s.calls, "before")
+		return req.URL.Query().Get("continue") == "true"
+	})
+
+	before(s.handler(http.StatusOK)).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/?continue=true", nil))
+	s.Equal([]string{"before", "handler"}, s.calls)
+
+	s.calls = nil
+	before(s.handler(http.StatusOK)).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal([]string{"before"}, s.calls)
+}
+
+func (s *MiddlewareSuite) TestAfterHookMiddleware() {
+	var hookWriter http.ResponseWriter
+	after := AfterHookMiddleware(func(res http.ResponseWriter) {
+		s.calls = append(s.calls, "after")
+		hookWriter = res
+	})
+
+	w := httptest.NewRecorder()
+	after(s.handler(http.StatusCreated)).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal([]string{"handler", "after"}, s.calls)
+	s.Same(w, hookWriter)
+
+	s.calls = nil
+	after(s.handler(http.StatusBadRequest)).ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal([]string{"handler"}, s.calls)
+}
+
+func (s *MiddlewareSuite) TestNilHooks() {
+	h := Chain(BeforeHookMiddleware(nil), AfterHookMiddleware(nil), ResponseHookMiddleware(nil))(s.handler(http.StatusOK))
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal([]string{"handler"}, s.calls)
+}
+
+func (s *MiddlewareSuite) TestResponseHookMiddleware() {
+	var got ResponseInfo
+	var gotPath string
+	hook := ResponseHookMiddleware(func(res http.ResponseWriter, req *http.Request, info ResponseInfo) {
+		s.calls = append(s.calls, "hook")
+		gotPath = req.URL.Path
+		got = info
+		res.Header().Set("Access-Control-Allow-Origin", "*")
+	})
+
+	h := hook(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		s.calls = append(s.calls, "handler")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"error":"not found"}`))
+	}))
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/1", nil))
+
+	s.Equal([]string{"handler", "hook"}, s.calls)
+	s.Equal("/products/1", gotPath)
+	s.Equal(http.StatusNotFound, got.Status)
+	s.Equal(`{"error":"not found"}`, string(got.Body))
+	s.Positive(got.Elapsed)
+
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Equal(`{"error":"not found"}`, w.Body.String())
+	s.Equal("*", w.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (s *MiddlewareSuite) TestResponseHookMiddlewareImplicitStatus() {
+	var got ResponseInfo
+	hook := ResponseHookMiddleware(func(res http.ResponseWriter, req *http.Request, info ResponseInfo) {
+		got = info
+	})
+
+	w := httptest.NewRecorder()
+	hook(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusOK, got.Status)
+	s.Empty(got.Body)
+	s.Equal(http.StatusOK, w.Code)
+}
+
+func (s *MiddlewareSuite) TestResponseHookMiddlewareFlush() {
+	var got ResponseInfo
+	hook := ResponseHookMiddleware(func(res http.ResponseWriter, req *http.Request, info ResponseInfo) {
+		got = info
+		res.Header().Set("X-Too-Late", "true")
+	})
+
+	w := httptest.NewRecorder()
+	hook(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		w.Write([]byte("first,"))
+		w.(http.Flusher).Flush()
+		s.Equal("first,", w.(interface{ Unwrap() http.ResponseWriter }).Unwrap().(*httptest.ResponseRecorder).Body.String())
+		w.Write([]byte("second"))
+	})).ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal("first,second", w.Body.String())
+	s.Equal("first,second", string(got.Body))
+	s.True(w.Flushed)
+	s.Empty(w.Result().Header.Get("X-Too-Late"))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestMiddlewareSuite(t *testing.T) {
+	suite.Run(t, new(MiddlewareSuite))
+}
@@ -0,0 +1,55 @@
+package handler
+
+import (
+	"fmt"
+	"log/slog"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+// PanicHook is a callback function called whenever a handler panics.
+// It is passed the request being handled, the recovered value and the stack trace, so panics can be reported to an error tracker.
+type PanicHook func(req *http.Request, recovered interface{}, stack []byte)
+
+// HandlePanic logs a recovered panic along with its stack trace and passes it to the given hooks.
+// It must be called from the deferred function which recovered the panic for the stack to be meaningful.
+func HandlePanic(req *http.Request, recovered interface{}, hooks ...PanicHook) {
+	stack := debug.Stack()
+
+	slog.Error("handler panicked", "panic", fmt.Sprint(recovered), "stack", string(stack))
+
+	for _, hook := range hooks {
+		if hook != nil {
+			hook(req, recovered, stack)
+		}
+	}
+}
+
+// PanicError returns the service error written to the client when a handler panics.
+func PanicError() *serviceerror.ServiceError {
+	return serviceerror.InternalServerError("An internal error occurred")
+}
+
+// Recover wraps h so a panic is recovered, passed to hooks and answered with a 500 INTERNAL_SERVER_ERROR service error.
+// http.ErrAbortHandler is re-panicked so net/http can abort the response as intended.
+func Recover(h http.HandlerFunc, hooks ...PanicHook) http.HandlerFunc {
+	return func(w http.ResponseWriter, req *http.Request) {
+		defer func() {
+			recovered := recover()
+			if recovered == nil {
+				return
+			}
+
+			if recovered == http.ErrAbortHandler {
+				panic(recovered)
+			}
+
+			HandlePanic(req, recovered, hooks...)
+			NewResponseHandler().BuildErrorResponse(w, PanicError())
+		}()
+
+		h(w, req)
+	}
+}
@@ -0,0 +1,58 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RecoverSuite struct {
+	suite.Suite
+	resp *reponseWriter
+}
+
+func (s *RecoverSuite) SetupTest() {
+	s.resp = &reponseWriter{}
+}
+
+func (s *RecoverSuite) TestRecover() {
+	var hookRecovered interface{}
+	var hookStack []byte
+	h := Recover(func(w http.ResponseWriter, req *http.Request) {
+		panic("boom")
+	}, func(req *http.Request, recovered interface{}, stack []byte) {
+		hookRecovered = recovered
+		hookStack = stack
+	})
+
+	s.NotPanics(func() {
+		h(s.resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+
+	s.Equal(http.StatusInternalServerError, s.resp.Status)
+	s.Equal(`{"error":{"id":"INTERNAL_SERVER_ERROR","code":"INTERNAL_SERVER_ERROR","message":"An internal error occurred"}}`, string(s.resp.Body))
+	s.Equal("boom", hookRecovered)
+	s.Contains(string(hookStack), "recover_test.go")
+}
+
+func (s *RecoverSuite) TestPanicErrorIsNotShared() {
+	s.NotSame(PanicError(), PanicError())
+}
+
+func (s *RecoverSuite) TestRecoverAbortHandler() {
+	h := Recover(func(w http.ResponseWriter, req *http.Request) {
+		panic(http.ErrAbortHandler)
+	})
+
+	s.PanicsWithValue(http.ErrAbortHandler, func() {
+		h(s.resp, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRecoverSuite(t *testing.T) {
+	suite.Run(t, new(RecoverSuite))
+}
@@ -0,0 +1,195 @@
+package handler
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+// stageContextKey is the unexported key under which the stage is stored in a request context.
+type stageContextKey struct{}
+
+// WithStage returns a copy of ctx carrying the API stage the request was received on.
+// The aws adapters set it from the API Gateway request context.
+func WithStage(ctx context.Context, stage string) context.Context {
+	return context.WithValue(ctx, stageContextKey{}, stage)
+}
+
+// StageFromContext returns the stage stored in ctx by WithStage, or an empty string.
+func StageFromContext(ctx context.Context) string {
+	stage, _ := ctx.Value(stageContextKey{}).(string)
+	return stage
+}
+
+// resourceContextKey is the unexported key under which the resource template is stored in a request context.
+type resourceContextKey struct{}
+
+// WithResource returns a copy of ctx carrying the API Gateway resource template, e.g. /products/{id},
+// the request was routed by. The aws adapters set it from the API Gateway request context.
+func WithResource(ctx context.Context, resource string) context.Context {
+	return context.WithValue(ctx, resourceContextKey{}, resource)
+}
+
+// ResourceFromContext returns the resource template stored in ctx by WithResource, or an empty string.
+func ResourceFromContext(ctx context.Context) string {
+	resource, _ := ctx.Value(resourceContextKey{}).(string)
+	return resource
+}
+
+// MuxTemplate converts an API Gateway resource template into the equivalent gorilla mux path template,
+// turning greedy {name+} parameters into {name:.+}.
+func MuxTemplate(resource string) string {
+	parts := strings.Split(resource, "/")
+	for i, part := range parts {
+		if strings.HasPrefix(part, "{") && strings.HasSuffix(part, "+}") {
+			parts[i] = strings.TrimSuffix(part, "+}") + ":.+}"
+		}
+	}
+
+	return strings.Join(parts, "/")
+}
+
+// Request is a Requester backed by a *http.Request.
+type Request struct {
+	req *http.Request
+}
+
+// NewRequest creates a Requester for req.
+func NewRequest(req *http.Request) *Request {
+	return &Request{req: req}
+}
+
+// HttpRequest returns the underlying request.
+func (r *Request) HttpRequest() *http.Request {
+	return r.req
+}
+
+func (r *Request) AddCookie(c *http.Cookie) {
+	r.req.AddCookie(c)
+}
+
+// Body returns the request body. The body is restored afterwards so it can be read again.
+func (r *Request) Body() string {
+	if r.req.Body == nil {
+		return ""
+	}
+
+	body, err := io.ReadAll(r.req.Body)
+	r.req.Body.Close()
+	r.req.Body = io.NopCloser(bytes.NewReader(body))
+
+	if err != nil {
+		return ""
+	}
+
+	return string(body)
+}
+
+func (r *Request) Context() Contexter {
+	return NewContext(r.req)
+}
+
+func (r *Request) Cookie(name string) (*http.Cookie, error) {
+	return r.req.Cookie(name)
+}
+
+func (r *Request) Cookies() []*http.Cookie {
+	return r.req.Cookies()
+}
+
+// GetAuthToken returns the Authorization header, without the Bearer scheme if present.
+func (r *Request) GetAuthToken() string {
+	token := r.req.Header.Get("Authorization")
+	if len(token) > len("Bearer ") && strings.EqualFold(token[:len("Bearer ")], "Bearer ") {
+		return token[len("Bearer "):]
+	}
+
+	return token
+}
+
+func (r *Request) Headers() http.Header {
+	return r.req.Header
+}
+
+func (r *Request) MultipartReader() (*multipart.Reader, error) {
+	return r.req.MultipartReader()
+}
+
+// PathByName returns the named path parameter, as set by the mux router or the aws adapters.
+func (r *Request) PathByName(name string) string {
+	return mux.Vars(r.req)[name]
+}
+
+func (r *Request) QueryByName(name string) string {
+	return r.req.URL.Query().Get(name)
+}
+
+func (r *Request) QueryParams() url.Values {
+	return r.req.URL.Query()
+}
+
+func (r *Request) Referer() string {
+	return r.req.Referer()
+}
+
+func (r *Request) SetQueryByName(name, set string) {
+	q := r.req.URL.Query()
+	q.Set(name, set)
+	r.req.URL.RawQuery = q.Encode()
+}
+
+func (r *Request) UserAgent() string {
+	return r.req.UserAgent()
+}
+
+// Context is a Contexter backed by a *http.Request.
+type Context struct {
+	req *http.Request
+}
+
+// NewContext creates a Contexter for req.
+func NewContext(req *http.Request) *Context {
+	return &Context{req: req}
+}
+
+// SourceIP returns the client IP address, which the aws adapters take from the API Gateway or load balancer request.
+func (c *Context) SourceIP() string {
+	if host, _, err := net.SplitHostPort(c.req.RemoteAddr); err == nil {
+		return host
+	}
+
+	return c.req.RemoteAddr
+}
+
+func (c *Context) UnixNow() int64 {
+	return time.Now().Unix()
+}
+
+func (c *Context) UserAgent() string {
+	return c.req.UserAgent()
+}
+
+func (c *Context) HttpMethod() string {
+	return c.req.Method
+}
+
+// Stage returns the API stage the request was received on, empty when not run behind API Gateway.
+func (c *Context) Stage() string {
+	return StageFromContext(c.req.Context())
+}
+
+// Adapt turns h into a http.HandlerFunc, writing any error it returns with ResponseHandler.BuildErrorResponse.
+// As with HandleError, exactly one response is written.
+func Adapt(h HandlerFunc) http.HandlerFunc {
+	return HandleError(func(w http.ResponseWriter, req *http.Request) error {
+		return h(w, NewRequest(req))
+	})
+}
@@ -0,0 +1,122 @@
+package handler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type RequestSuite struct {
+	suite.Suite
+}
+
+func (s *RequestSuite) TestRequest() {
+	req := httptest.NewRequest(http.MethodPost, "/products/ABC123?postcode=AB1&page=2", strings.NewReader(`{"name":"test"}`))
+	req.Header.Set("Authorization", "Bearer authToken")
+	req.Header.Set("User-Agent", "test-agent")
+	req.Header.Set("Referer", "https://example.com")
+	req.AddCookie(&http.Cookie{Name: "session", Value: "abc"})
+	req = mux.SetURLVars(req, map[string]string{"id": "ABC123"})
+
+	r := NewRequest(req)
+
+	s.Equal("ABC123", r.PathByName("id"))
+	s.Equal("AB1", r.QueryByName("postcode"))
+	s.Equal("2", r.QueryParams().Get("page"))
+	s.Equal("authToken", r.GetAuthToken())
+	s.Equal("test-agent", r.UserAgent())
+	s.Equal("https://example.com", r.Referer())
+	s.Equal("Bearer authToken", r.Headers().Get("Authorization"))
+
+	cookie, err := r.Cookie("session")
+	s.NoError(err)
+	s.Equal("abc", cookie.Value)
+
+	r.AddCookie(&http.Cookie{Name: "theme", Value: "dark"})
+	s.Len(r.Cookies(), 2)
+
+	r.SetQueryByName("page", "3")
+	s.Equal("3", r.QueryByName("page"))
+	s.Equal("3", req.URL.Query().Get("page"))
+
+	s.Equal(`{"name":"test"}`, r.Body())
+	s.Equal(`{"name":"test"}`, r.Body())
+
+	body, err := io.ReadAll(r.HttpRequest().Body)
+	s.NoError(err)
+	s.Equal(`{"name":"test"}`, string(body))
+}
+
+func (s *RequestSuite) TestGetAuthTokenWithoutScheme() {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "authToken")
+
+	s.Equal("authToken", NewRequest(req).GetAuthToken())
+}
+
+func (s *RequestSuite) TestContext() {
+	req := httptest.NewRequest(http.MethodDelete, "/", nil)
+	req.RemoteAddr = "203.0.113.1:443"
+	req.Header.Set("User-Agent", "test-agent")
+
+	c := NewRequest(req).Context()
+	s.Equal("203.0.113.1", c.SourceIP())
+	s.Equal(http.MethodDelete, c.HttpMethod())
+	s.Equal("test-agent", c.UserAgent())
+	s.Equal("", c.Stage())
+	s.Positive(c.UnixNow())
+
+	req = req.WithContext(WithStage(req.Context(), "prod"))
+	req.RemoteAddr = "203.0.113.1"
+
+	c = NewContext(req)
+	s.Equal("prod", c.Stage())
+	s.Equal("203.0.113.1", c.SourceIP())
+}
+
+func (s *RequestSuite) TestMuxTemplate() {
+	s.Equal("/products/{id}", MuxTemplate("/products/{id}"))
+	s.Equal("/files/{proxy:.+}", MuxTemplate("/files/{proxy+}"))
+}
+
+func (s *RequestSuite) TestAdapt() {
+	h := Adapt(func(res http.ResponseWriter, req Requester) error {
+		if req.QueryByName("id") == "" {
+			return serviceerror.BadRequest("id required")
+		}
+
+		if req.QueryByName("id") == "broken" {
+			return errors.New("connection refused")
+		}
+
+		return NewResponseHandler().BuildResponse(res, http.StatusOK, Model{Success: true})
+	})
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?id=1", nil))
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal(`{"success":true}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.JSONEq(`{"error":{"id":"BAD_REQUEST","code":"BAD_REQUEST","message":"id required"}}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/?id=broken", nil))
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.JSONEq(`{"error":{"id":"UNKNOWN_ERROR","code":"UNKNOWN_ERROR","message":"An unknown error occurred"}}`, w.Body.String())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRequestSuite(t *testing.T) {
+	suite.Run(t, new(RequestSuite))
+}
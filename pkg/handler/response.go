@@ -2,8 +2,11 @@ package handler
 
 import (
 	"encoding/json"
+	"errors"
 	"log/slog"
 	"net/http"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
 )
 
 // Genertic Handler object which is the reciever in every handler method
@@ -74,6 +77,8 @@ func (r *ResponseHandler) BuildResponder(
 	return r.BuildResponderWithHeader(res, code, body, http.Header{})
 }
 
+// BuildErrorResponse writes err with its status code, or as a 500 UNKNOWN_ERROR if it is not a service error.
+// The error is rendered as a service error unless the response writer carries ProblemFormat (see NegotiateErrorFormat).
 func (r *ResponseHandler) BuildErrorResponse(
 	res http.ResponseWriter,
 	err error,
@@ -89,44 +94,35 @@ func (r *ResponseHandler) BuildErrorResponseWithHeader(
 	statusCode := http.StatusInternalServerError
 	var serviceErr error
 
-	isServiceError, code := isServiceError(err)
-
-	if isServiceError {
-		statusCode = code
-		serviceErr = err
+	// A service error wrapped with fmt.Errorf("...: %w", err) is written as the service error itself.
+	if se, ok := isServiceError(err); ok {
+		statusCode = se.StatusCode()
+		serviceErr = se
 	} else {
 		// If its a general error - we don't want to return the message as its a code/integration issue.
 		// We don't want those messages being shown to users.
-		serviceErr = &ServiceError{
-			Err: Error{
-				ID:      "UNKNOWN_ERROR",
-				Code:    "UNKNOWN_ERROR",
-				Message: "An unknown error occurred",
-			},
-		}
+		serviceErr = NewServiceError(serviceerror.CodeUnknown, serviceerror.CodeUnknown, "An unknown error occurred")
 	}
 
 	if statusCode == http.StatusInternalServerError {
 		slog.Error(err.Error())
 	}
 
-	return r.BuildResponseWithHeader(res, statusCode, serviceErr, headers)
+	return writeError(res, statusCode, serviceErr, headers)
 }
 
-func isServiceError(err error) (bool, int) {
-	var code int
-
-	type serviceError interface {
-		Code() string
-		Error() string
-		StatusCode() int
-	}
-
-	se, isSe := err.(serviceError)
+type serviceError interface {
+	Code() string
+	Error() string
+	StatusCode() int
+}
 
-	if isSe {
-		code = se.StatusCode()
+// isServiceError finds the first service error in err's chain.
+func isServiceError(err error) (serviceError, bool) {
+	var se serviceError
+	if errors.As(err, &se) {
+		return se, true
 	}
 
-	return isSe, code
+	return nil, false
 }
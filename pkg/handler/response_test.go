@@ -1,9 +1,12 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -100,6 +103,22 @@ func (s *ResponseHandlerSuite) TestBuildResponseWithHeader_Cookie() {
 	s.Equal(headers, s.resp.Headers)
 }
 
+func (s *ResponseHandlerSuite) TestBuildErrorResponse_Wrapped() {
+	err := fmt.Errorf("loading user: %w", serviceerror.NotFound("User not found"))
+
+	s.NoError(s.handler.BuildErrorResponse(s.resp, err))
+
+	s.Equal(http.StatusNotFound, s.resp.Status)
+	s.JSONEq(`{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"User not found"}}`, string(s.resp.Body))
+}
+
+func (s *ResponseHandlerSuite) TestBuildErrorResponse_Unknown() {
+	s.NoError(s.handler.BuildErrorResponse(s.resp, fmt.Errorf("loading user: %w", errors.New("connection refused"))))
+
+	s.Equal(http.StatusInternalServerError, s.resp.Status)
+	s.JSONEq(`{"error":{"id":"UNKNOWN_ERROR","code":"UNKNOWN_ERROR","message":"An unknown error occurred"}}`, string(s.resp.Body))
+}
+
 // In order for 'go test' to run this suite, we need to create
 // a normal test function and pass our suite to suite.Run
 func TestResponseHandlerSuite(t *testing.T) {
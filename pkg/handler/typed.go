@@ -0,0 +1,33 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+)
+
+// TypedHandlerFunc is a handler which receives its request already bound into Req and returns the model to respond with.
+type TypedHandlerFunc[Req, Resp any] func(ctx context.Context, req Req) (Resp, error)
+
+// Typed turns h into a http.HandlerFunc. The request is bound into Req and validated with Bind, a binding or
+// validation failure being answered with a 400 BAD_REQUEST service error, and the returned Resp is written as a
+// 200 JSON response with ResponseHandler.BuildResponse. As with HandleError, a returned error is written with
+// BuildErrorResponse.
+//
+//	http.HandleFunc("/products/{id}", handler.Typed(func(ctx context.Context, req GetProductRequest) (Product, error) {
+//		return store.Get(ctx, req.ID)
+//	}))
+func Typed[Req, Resp any](h TypedHandlerFunc[Req, Resp]) http.HandlerFunc {
+	return HandleError(func(w http.ResponseWriter, r *http.Request) error {
+		var req Req
+		if err := Bind(r, &req); err != nil {
+			return err
+		}
+
+		resp, err := h(r.Context(), req)
+		if err != nil {
+			return err
+		}
+
+		return NewResponseHandler().BuildResponse(w, http.StatusOK, resp)
+	})
+}
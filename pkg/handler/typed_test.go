@@ -0,0 +1,88 @@
+package handler
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type getProductRequest struct {
+	ID    string `path:"id"`
+	Token string `header:"Authorization"`
+	Count int    `query:"count"`
+	Name  string `json:"name"`
+}
+
+type product struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+type TypedSuite struct {
+	suite.Suite
+	handler http.HandlerFunc
+}
+
+type ctxKey struct{}
+
+func (s *TypedSuite) SetupTest() {
+	s.handler = Typed(func(ctx context.Context, req getProductRequest) (product, error) {
+		s.Equal("value", ctx.Value(ctxKey{}))
+
+		if req.Token != "authToken" {
+			return product{}, serviceerror.Unauthorized("Invalid token")
+		}
+
+		return product{ID: req.ID, Name: req.Name, Count: req.Count}, nil
+	})
+}
+
+func (s *TypedSuite) serve(target, token, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPut, target, strings.NewReader(body))
+	req.Header.Set("Authorization", token)
+	req = mux.SetURLVars(req, map[string]string{"id": "ABC123"})
+	req = req.WithContext(context.WithValue(req.Context(), ctxKey{}, "value"))
+
+	w := httptest.NewRecorder()
+	s.handler(w, req)
+
+	return w
+}
+
+func (s *TypedSuite) TestTyped() {
+	w := s.serve("/products/ABC123?count=3", "authToken", `{"name":"Widget"}`)
+
+	s.Equal(http.StatusOK, w.Code)
+	s.JSONEq(`{"id":"ABC123","name":"Widget","count":3}`, w.Body.String())
+}
+
+func (s *TypedSuite) TestTypedBindingFailure() {
+	w := httptest.NewRecorder()
+	Typed(func(ctx context.Context, req getProductRequest) (product, error) {
+		s.Fail("handler should not be called")
+		return product{}, nil
+	})(w, httptest.NewRequest(http.MethodPut, "/products/ABC123?count=many", nil))
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.JSONEq(`{"error":{"id":"BAD_REQUEST","code":"BAD_REQUEST","message":"Invalid query parameter \"count\": \"many\" is not a valid integer"}}`, w.Body.String())
+}
+
+func (s *TypedSuite) TestTypedError() {
+	w := s.serve("/products/ABC123", "wrong", "")
+
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.JSONEq(`{"error":{"id":"UNAUTHORIZED","code":"UNAUTHORIZED","message":"Invalid token"}}`, w.Body.String())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestTypedSuite(t *testing.T) {
+	suite.Run(t, new(TypedSuite))
+}
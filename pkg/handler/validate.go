@@ -0,0 +1,275 @@
+package handler
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+// ValidationFailedMessage is the message of the BAD_REQUEST service error returned by Validate.
+const ValidationFailedMessage = "Validation failed"
+
+var patterns sync.Map // map[string]*regexp.Regexp
+
+// Validate checks v, a struct or pointer to a struct, against the rules in its validate struct tags:
+//
+//	type CreateOrderRequest struct {
+//		Reference string    `json:"reference" validate:"required,max=20,regexp=^[A-Z0-9-]+$"`
+//		Status    string    `json:"status" validate:"omitempty,enum=open|closed"`
+//		Quantity  int       `json:"quantity" validate:"min=1,max=100"`
+//		Items     []Item    `json:"items" validate:"required,max=50"`
+//		Priority  *int      `json:"priority" validate:"enum=1|2|3"`
+//		Address   *Address  `json:"address"`
+//	}
+//
+// required fails on a zero value. min and max bound the value of numbers and the length of strings, slices and maps.
+// regexp matches strings against a pattern and, as the pattern may contain commas, must be the last rule.
+// enum lists the allowed values separated by |. These rules apply to zero values too, so min=1 rejects 0 and "",
+// but are skipped for a nil pointer, or for any zero value once omitempty has been given.
+// Nested structs, including those in pointers, slices and map values, are validated too.
+//
+// Every failing field is returned as a BAD_REQUEST service error listing a FieldError for each,
+// located by the field's json (or path, query or header) name.
+func Validate(v any) error {
+	var fields []serviceerror.FieldError
+	if err := validateValue(reflect.ValueOf(v), "", &fields); err != nil {
+		return err
+	}
+
+	if len(fields) > 0 {
+		return serviceerror.BadRequestWithFields(ValidationFailedMessage, fields)
+	}
+
+	return nil
+}
+
+func validateValue(rv reflect.Value, path string, fields *[]serviceerror.FieldError) error {
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+
+		return validateValue(rv.Elem(), path, fields)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			if err := validateValue(rv.Index(i), fmt.Sprintf("%s[%d]", path, i), fields); err != nil {
+				return err
+			}
+		}
+	case reflect.Map:
+		keys := rv.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface())
+		})
+
+		for _, key := range keys {
+			if err := validateValue(rv.MapIndex(key), fmt.Sprintf("%s[%v]", path, key.Interface()), fields); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		return validateStruct(rv, path, fields)
+	}
+
+	return nil
+}
+
+func validateStruct(rv reflect.Value, path string, fields *[]serviceerror.FieldError) error {
+	rt := rv.Type()
+	for i := 0; i < rt.NumField(); i++ {
+		field := rt.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		fv := rv.Field(i)
+		if field.Anonymous && fv.Kind() == reflect.Struct {
+			if err := validateStruct(fv, path, fields); err != nil {
+				return err
+			}
+
+			continue
+		}
+
+		fieldPath := joinPath(path, fieldName(field))
+		if tag := field.Tag.Get("validate"); tag != "" {
+			failed, err := validateField(fv, fieldPath, tag)
+			if err != nil {
+				return fmt.Errorf("invalid validate tag on field %s: %w", field.Name, err)
+			}
+
+			if failed != nil {
+				*fields = append(*fields, *failed)
+				continue
+			}
+		}
+
+		if err := validateValue(fv, fieldPath, fields); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// validateField checks fv against the rules of tag, returning the first which fails.
+func validateField(fv reflect.Value, path, tag string) (*serviceerror.FieldError, error) {
+	for _, rule := range splitRules(tag) {
+		name, arg, _ := strings.Cut(rule, "=")
+
+		switch name {
+		case "required":
+			if fv.IsZero() {
+				return &serviceerror.FieldError{Path: path, Rule: name, Message: fmt.Sprintf("%s is required", path)}, nil
+			}
+
+			continue
+		case "omitempty":
+			if fv.IsZero() {
+				return nil, nil
+			}
+
+			continue
+		}
+
+		if fv.Kind() == reflect.Pointer && fv.IsNil() {
+			return nil, nil
+		}
+
+		value := reflect.Indirect(fv)
+
+		var message string
+		var err error
+		switch name {
+		case "min", "max":
+			message, err = checkBound(value, path, name, arg)
+		case "regexp":
+			message, err = checkPattern(value, path, arg)
+		case "enum":
+			message = checkEnum(value, path, arg)
+		default:
+			err = fmt.Errorf("unknown rule %q", name)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		if message != "" {
+			return &serviceerror.FieldError{Path: path, Rule: name, Message: message}, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// splitRules splits a validate tag on commas, leaving the pattern of a regexp rule intact.
+func splitRules(tag string) []string {
+	var rules []string
+	for tag != "" {
+		if strings.HasPrefix(tag, "regexp=") {
+			return append(rules, tag)
+		}
+
+		rule, rest, _ := strings.Cut(tag, ",")
+		rules = append(rules, strings.TrimSpace(rule))
+		tag = rest
+	}
+
+	return rules
+}
+
+func checkBound(value reflect.Value, path, rule, arg string) (string, error) {
+	limit, err := strconv.ParseFloat(arg, 64)
+	if err != nil {
+		return "", fmt.Errorf("%s must be a number: %w", rule, err)
+	}
+
+	var n float64
+	var unit string
+	switch value.Kind() {
+	case reflect.String:
+		n, unit = float64(utf8.RuneCountInString(value.String())), " characters"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		n, unit = float64(value.Len()), " items"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n = float64(value.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n = float64(value.Uint())
+	case reflect.Float32, reflect.Float64:
+		n = value.Float()
+	default:
+		return "", fmt.Errorf("%s cannot be applied to %s", rule, value.Type())
+	}
+
+	if rule == "min" && n < limit {
+		return fmt.Sprintf("%s must be at least %s%s", path, arg, unit), nil
+	}
+
+	if rule == "max" && n > limit {
+		return fmt.Sprintf("%s must be at most %s%s", path, arg, unit), nil
+	}
+
+	return "", nil
+}
+
+func checkPattern(value reflect.Value, path, pattern string) (string, error) {
+	if value.Kind() != reflect.String {
+		return "", fmt.Errorf("regexp cannot be applied to %s", value.Type())
+	}
+
+	re, ok := patterns.Load(pattern)
+	if !ok {
+		compiled, err := regexp.Compile(pattern)
+		if err != nil {
+			return "", err
+		}
+
+		re, _ = patterns.LoadOrStore(pattern, compiled)
+	}
+
+	if !re.(*regexp.Regexp).MatchString(value.String()) {
+		return fmt.Sprintf("%s must match %s", path, pattern), nil
+	}
+
+	return "", nil
+}
+
+func checkEnum(value reflect.Value, path, arg string) string {
+	allowed := strings.Split(arg, "|")
+	actual := fmt.Sprint(value.Interface())
+	for _, a := range allowed {
+		if a == actual {
+			return ""
+		}
+	}
+
+	return fmt.Sprintf("%s must be one of %s", path, strings.Join(allowed, ", "))
+}
+
+// fieldName is the name a field is known by in the request.
+func fieldName(field reflect.StructField) string {
+	for _, tag := range []string{"json", "path", "query", "header"} {
+		if name, _, _ := strings.Cut(field.Tag.Get(tag), ","); name != "" && name != "-" {
+			return name
+		}
+	}
+
+	return field.Name
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+
+	return path + "." + name
+}
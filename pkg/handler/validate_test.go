@@ -0,0 +1,189 @@
+package handler
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type address struct {
+	Line1    string `json:"line1" validate:"required"`
+	Postcode string `json:"postcode" validate:"required,regexp=^[A-Z]{1,2}[0-9][A-Z0-9]?,? ?[0-9][A-Z]{2}$"`
+}
+
+type orderItem struct {
+	SKU      string `json:"sku" validate:"required,min=3,max=8"`
+	Quantity int    `json:"quantity" validate:"min=1,max=100"`
+}
+
+type createOrderRequest struct {
+	Page      int                  `query:"page" validate:"omitempty,min=1"`
+	Reference string               `json:"reference" validate:"required,max=10"`
+	Status    string               `json:"status" validate:"omitempty,enum=open|closed"`
+	Priority  *int                 `json:"priority" validate:"enum=1|2|3"`
+	Address   *address             `json:"address" validate:"required"`
+	Items     []orderItem          `json:"items" validate:"required,max=2"`
+	Gifts     map[string]orderItem `json:"gifts" validate:"max=2"`
+	Notes     string               `json:"-" validate:"max=5"`
+}
+
+type ValidateSuite struct {
+	suite.Suite
+}
+
+func (s *ValidateSuite) valid() createOrderRequest {
+	return createOrderRequest{
+		Reference: "ORDER-1",
+		Status:    "open",
+		Address:   &address{Line1: "1 Road", Postcode: "AB1 2CD"},
+		Items:     []orderItem{{SKU: "SKU1", Quantity: 1}},
+	}
+}
+
+func (s *ValidateSuite) fields(err error) []serviceerror.FieldError {
+	var se *serviceerror.ServiceError
+	s.Require().ErrorAs(err, &se)
+	s.Equal(http.StatusBadRequest, se.StatusCode())
+	s.Equal(ValidationFailedMessage, se.Error())
+
+	return se.Err.Fields
+}
+
+func (s *ValidateSuite) TestValid() {
+	req := s.valid()
+	s.NoError(Validate(req))
+	s.NoError(Validate(&req))
+}
+
+func (s *ValidateSuite) TestRules() {
+	priority := 4
+	req := s.valid()
+	req.Reference = ""
+	req.Status = "pending"
+	req.Priority = &priority
+	req.Address.Postcode = "not a postcode"
+	req.Items = []orderItem{{SKU: "AB", Quantity: 101}, {SKU: "SKU1", Quantity: 1}, {SKU: "SKU2", Quantity: 1}}
+	req.Notes = "too long"
+
+	s.Equal([]serviceerror.FieldError{
+		{Path: "reference", Rule: "required", Message: "reference is required"},
+		{Path: "status", Rule: "enum", Message: "status must be one of open, closed"},
+		{Path: "priority", Rule: "enum", Message: "priority must be one of 1, 2, 3"},
+		{Path: "address.postcode", Rule: "regexp", Message: "address.postcode must match ^[A-Z]{1,2}[0-9][A-Z0-9]?,? ?[0-9][A-Z]{2}$"},
+		{Path: "items", Rule: "max", Message: "items must be at most 2 items"},
+		{Path: "Notes", Rule: "max", Message: "Notes must be at most 5 characters"},
+	}, s.fields(Validate(req)))
+}
+
+func (s *ValidateSuite) TestNested() {
+	req := s.valid()
+	req.Address = &address{}
+	req.Items = []orderItem{{SKU: "SKU1", Quantity: 1}, {SKU: "TOOLONGSKU", Quantity: 101}}
+
+	s.Equal([]serviceerror.FieldError{
+		{Path: "address.line1", Rule: "required", Message: "address.line1 is required"},
+		{Path: "address.postcode", Rule: "required", Message: "address.postcode is required"},
+		{Path: "items[1].sku", Rule: "max", Message: "items[1].sku must be at most 8 characters"},
+		{Path: "items[1].quantity", Rule: "max", Message: "items[1].quantity must be at most 100"},
+	}, s.fields(Validate(req)))
+}
+
+func (s *ValidateSuite) TestNestedMap() {
+	req := s.valid()
+	req.Gifts = map[string]orderItem{
+		"bob":   {SKU: "SKU1", Quantity: 0},
+		"alice": {SKU: "S", Quantity: 1},
+	}
+
+	s.Equal([]serviceerror.FieldError{
+		{Path: "gifts[alice].sku", Rule: "min", Message: "gifts[alice].sku must be at least 3 characters"},
+		{Path: "gifts[bob].quantity", Rule: "min", Message: "gifts[bob].quantity must be at least 1"},
+	}, s.fields(Validate(req)))
+}
+
+func (s *ValidateSuite) TestRequiredNested() {
+	req := s.valid()
+	req.Address = nil
+	req.Items = nil
+
+	s.Equal([]serviceerror.FieldError{
+		{Path: "address", Rule: "required", Message: "address is required"},
+		{Path: "items", Rule: "required", Message: "items is required"},
+	}, s.fields(Validate(req)))
+}
+
+func (s *ValidateSuite) TestZeroValues() {
+	var req struct {
+		Quantity int      `json:"quantity" validate:"min=1"`
+		Name     string   `json:"name" validate:"min=1"`
+		Tags     []string `json:"tags" validate:"min=1"`
+		Status   string   `json:"status" validate:"enum=open|closed"`
+		Page     int      `json:"page" validate:"omitempty,min=1"`
+		Limit    *int     `json:"limit" validate:"min=1"`
+	}
+
+	s.Equal([]serviceerror.FieldError{
+		{Path: "quantity", Rule: "min", Message: "quantity must be at least 1"},
+		{Path: "name", Rule: "min", Message: "name must be at least 1 characters"},
+		{Path: "tags", Rule: "min", Message: "tags must be at least 1 items"},
+		{Path: "status", Rule: "enum", Message: "status must be one of open, closed"},
+	}, s.fields(Validate(req)))
+
+	zero := 0
+	req.Quantity, req.Name, req.Tags, req.Status, req.Limit = 1, "a", []string{"a"}, "open", &zero
+	s.Equal([]serviceerror.FieldError{
+		{Path: "limit", Rule: "min", Message: "limit must be at least 1"},
+	}, s.fields(Validate(req)))
+}
+
+func (s *ValidateSuite) TestInvalidTag() {
+	var req struct {
+		Name string `validate:"min=one"`
+	}
+	req.Name = "name"
+
+	err := Validate(req)
+	s.ErrorContains(err, "min must be a number")
+
+	var se *serviceerror.ServiceError
+	s.False(errors.As(err, &se))
+
+	var unknown struct {
+		Name string `validate:"unknown"`
+	}
+	unknown.Name = "name"
+	s.ErrorContains(Validate(unknown), `unknown rule "unknown"`)
+}
+
+func (s *ValidateSuite) TestBindValidates() {
+	body := `{"reference":"ORDER-1","address":{"line1":"1 Road","postcode":"AB1 2CD"},"items":[{"sku":"S","quantity":1}]}`
+	req := httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(body))
+
+	var got createOrderRequest
+	s.Equal([]serviceerror.FieldError{
+		{Path: "items[0].sku", Rule: "min", Message: "items[0].sku must be at least 3 characters"},
+	}, s.fields(Bind(req, &got)))
+
+	w := httptest.NewRecorder()
+	Typed(func(ctx context.Context, req createOrderRequest) (Model, error) {
+		return Model{Success: true}, nil
+	})(w, httptest.NewRequest(http.MethodPost, "/orders", strings.NewReader(`{"reference":"ORDER-1"}`)))
+
+	s.Equal(http.StatusBadRequest, w.Code)
+	s.JSONEq(`{"error":{"id":"BAD_REQUEST","code":"BAD_REQUEST","message":"Validation failed","fields":[
+		{"path":"address","rule":"required","message":"address is required"},
+		{"path":"items","rule":"required","message":"items is required"}
+	]}}`, w.Body.String())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateSuite))
+}
@@ -1,7 +1,27 @@
 package mux
 
-import "net/http"
+import (
+	"net/http"
 
-func CreateHandler(h http.HandlerFunc) func(w http.ResponseWriter, r *http.Request) {
-	return h
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+)
+
+// CreateHandler adapts h for use with a gorilla mux router, wrapping it in the given middleware
+// and recovering any panic as a 500 service error. A panic in h is recovered inside the middleware,
+// so middleware such as handler.ResponseHookMiddleware sees the 500 response.
+// Errors are rendered in the format negotiated by handler.NegotiateErrorFormat, and as with the aws response writers
+// only the first response is sent, see handler.WriteOnce. CreateHandlerWithOptions accepts every available option.
+func CreateHandler(h http.HandlerFunc, mw ...handler.Middleware) func(w http.ResponseWriter, r *http.Request) {
+	return CreateHandlerWithOptions(h, WithMiddleware(mw...))
+}
+
+// CreateHandlerWithOptions adapts h for use with a gorilla mux router as CreateHandler does, configured with opts.
+func CreateHandlerWithOptions(h http.HandlerFunc, opts ...Option) func(w http.ResponseWriter, r *http.Request) {
+	return newOptions(opts...).createHandler(h)
+}
+
+func (o *options) createHandler(h http.HandlerFunc) func(w http.ResponseWriter, r *http.Request) {
+	return handler.NegotiateErrorFormatWith(o.errorFormat)(handler.WriteOnce(
+		handler.Recover(handler.Chain(o.middleware...)(handler.Recover(h, o.panicHook)).ServeHTTP, o.panicHook),
+	)).ServeHTTP
 }
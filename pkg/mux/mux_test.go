@@ -0,0 +1,124 @@
+package mux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type MuxSuite struct {
+	suite.Suite
+}
+
+func (s *MuxSuite) TestCreateHandler() {
+	after := false
+	h := CreateHandler(
+		func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusOK)
+		},
+		handler.BeforeHookMiddleware(func(res http.ResponseWriter, req *http.Request) bool {
+			res.Header().Set("X-Before", "true")
+			return true
+		}),
+		handler.AfterHookMiddleware(func(res http.ResponseWriter) {
+			after = true
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusOK, w.Code)
+	s.Equal("true", w.Header().Get("X-Before"))
+	s.True(after)
+}
+
+func (s *MuxSuite) TestCreateHandlerWritesOnce() {
+	h := CreateHandler(func(w http.ResponseWriter, r *http.Request) {
+		handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.Unauthorized("Unauthorized"))
+		handler.NewResponseHandler().BuildResponse(w, http.StatusOK, map[string]bool{"success": true})
+	})
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusUnauthorized, w.Code)
+	s.Equal(`{"error":{"id":"UNAUTHORIZED","code":"UNAUTHORIZED","message":"Unauthorized"}}`, w.Body.String())
+}
+
+func (s *MuxSuite) TestCreateHandlerRecovers() {
+	h := CreateHandler(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	w := httptest.NewRecorder()
+	s.NotPanics(func() {
+		h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+}
+
+func (s *MuxSuite) TestCreateHandlerWithPanicHook() {
+	var recovered interface{}
+	h := CreateHandlerWithOptions(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		},
+		WithPanicHook(func(req *http.Request, r interface{}, stack []byte) {
+			recovered = r
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Equal("boom", recovered)
+}
+
+func (s *MuxSuite) TestCreateHandlerResponseHookSeesPanic() {
+	var status int
+	h := CreateHandler(
+		func(w http.ResponseWriter, r *http.Request) {
+			panic("boom")
+		},
+		handler.ResponseHookMiddleware(func(res http.ResponseWriter, req *http.Request, info handler.ResponseInfo) {
+			status = info.Status
+			res.Header().Set("X-Hook", "true")
+		}),
+	)
+
+	w := httptest.NewRecorder()
+	h(w, httptest.NewRequest(http.MethodGet, "/", nil))
+
+	s.Equal(http.StatusInternalServerError, status)
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Equal("true", w.Header().Get("X-Hook"))
+}
+
+func (s *MuxSuite) TestCreateHandlerProblemFormat() {
+	h := CreateHandler(func(w http.ResponseWriter, r *http.Request) {
+		panic("boom")
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/products", nil)
+	req.Header.Set("Accept", "application/problem+json")
+
+	w := httptest.NewRecorder()
+	h(w, req)
+
+	s.Equal(http.StatusInternalServerError, w.Code)
+	s.Equal("application/problem+json", w.Header().Get("Content-Type"))
+	s.JSONEq(`{"type":"about:blank","title":"Internal Server Error","status":500,"detail":"An internal error occurred","instance":"/products","code":"INTERNAL_SERVER_ERROR"}`, w.Body.String())
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestMuxSuite(t *testing.T) {
+	suite.Run(t, new(MuxSuite))
+}
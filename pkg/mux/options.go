@@ -0,0 +1,46 @@
+package mux
+
+import (
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+)
+
+// Option configures the handlers created by CreateHandlerWithOptions and NewRouter.
+type Option func(*options)
+
+type options struct {
+	middleware  []handler.Middleware
+	panicHook   handler.PanicHook
+	errorFormat handler.ErrorFormat
+}
+
+func newOptions(opts ...Option) *options {
+	o := &options{}
+
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// WithMiddleware appends middleware which wraps the handler, the first given being the outermost.
+func WithMiddleware(mw ...handler.Middleware) Option {
+	return func(o *options) {
+		o.middleware = append(o.middleware, mw...)
+	}
+}
+
+// WithPanicHook sets a hook called whenever the handler or its middleware panics, so panics can be reported to an error tracker.
+func WithPanicHook(hook handler.PanicHook) Option {
+	return func(o *options) {
+		o.panicHook = hook
+	}
+}
+
+// WithErrorFormat sets the format errors are rendered in, service errors by default, unless the request's Accept header
+// asks for application/problem+json or refuses it with q=0.
+func WithErrorFormat(format handler.ErrorFormat) Option {
+	return func(o *options) {
+		o.errorFormat = format
+	}
+}
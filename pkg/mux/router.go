@@ -0,0 +1,188 @@
+package mux
+
+import (
+	"net/http"
+	"sync"
+
+	gorilla "github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+)
+
+// Route is a single entry in a Router's route table.
+// Path uses API Gateway resource template syntax, e.g. /products/{id} or /files/{proxy+}.
+// An empty Method matches any method, like API Gateway's ANY.
+type Route struct {
+	Method  string
+	Path    string
+	Handler http.HandlerFunc
+}
+
+// Router is a route table which is declared once and served identically by gorilla mux locally
+// or inside a single Lambda function (a "monolambda"), or split into one Lambda function per route with HandlerFor.
+type Router struct {
+	mu       sync.Mutex
+	routes   []Route
+	options  options
+	router   *gorilla.Router
+	handlers []http.HandlerFunc
+}
+
+// NewRouter creates an empty Router whose routes are wrapped as CreateHandlerWithOptions would with opts.
+func NewRouter(opts ...Option) *Router {
+	return &Router{
+		options: *newOptions(opts...),
+	}
+}
+
+// Use appends middleware which wraps every route, in the order given.
+func (rt *Router) Use(mw ...handler.Middleware) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	WithMiddleware(mw...)(&rt.options)
+	rt.router = nil
+}
+
+// Handle registers h for the method and resource template path.
+func (rt *Router) Handle(method, path string, h http.HandlerFunc) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.routes = append(rt.routes, Route{
+		Method:  method,
+		Path:    path,
+		Handler: h,
+	})
+	rt.router = nil
+}
+
+// Get registers h for GET requests to path.
+func (rt *Router) Get(path string, h http.HandlerFunc) {
+	rt.Handle(http.MethodGet, path, h)
+}
+
+// Post registers h for POST requests to path.
+func (rt *Router) Post(path string, h http.HandlerFunc) {
+	rt.Handle(http.MethodPost, path, h)
+}
+
+// Put registers h for PUT requests to path.
+func (rt *Router) Put(path string, h http.HandlerFunc) {
+	rt.Handle(http.MethodPut, path, h)
+}
+
+// Patch registers h for PATCH requests to path.
+func (rt *Router) Patch(path string, h http.HandlerFunc) {
+	rt.Handle(http.MethodPatch, path, h)
+}
+
+// Delete registers h for DELETE requests to path.
+func (rt *Router) Delete(path string, h http.HandlerFunc) {
+	rt.Handle(http.MethodDelete, path, h)
+}
+
+// Routes returns a copy of the route table.
+func (rt *Router) Routes() []Route {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return append([]Route{}, rt.routes...)
+}
+
+// HandlerFor returns the handler, wrapped in the router's middleware, registered for method and path.
+// It allows each route to be deployed as its own Lambda function from the same route table.
+func (rt *Router) HandlerFor(method, path string) (http.HandlerFunc, bool) {
+	h, _ := rt.lookup(method, path)
+
+	return h, h != nil
+}
+
+// ServeHTTP dispatches req to the matching route.
+// Inside Lambda the route is chosen from the API Gateway resource template (or HTTP API route key) of the event,
+// see handler.ResourceFromContext, whose path parameters are already set by the aws package. A resource registered
+// for other methods only is answered with 405 METHOD_NOT_ALLOWED, as it is locally. Otherwise req is matched by gorilla mux.
+func (rt *Router) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	router := rt.build()
+
+	if resource := handler.ResourceFromContext(req.Context()); resource != "" {
+		h, registered := rt.lookup(req.Method, resource)
+		if h != nil {
+			h(w, req)
+			return
+		}
+
+		if registered {
+			router.MethodNotAllowedHandler.ServeHTTP(w, req)
+			return
+		}
+	}
+
+	router.ServeHTTP(w, req)
+}
+
+// lookup returns the handler registered for method and path, and whether path is registered for any method.
+func (rt *Router) lookup(method, path string) (http.HandlerFunc, bool) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	rt.buildLocked()
+
+	registered := false
+	for i, route := range rt.routes {
+		if route.Path != path {
+			continue
+		}
+
+		if route.Method == "" || route.Method == method {
+			return rt.handlers[i], true
+		}
+
+		registered = true
+	}
+
+	return nil, registered
+}
+
+// build creates the gorilla mux router and the middleware wrapped handlers for the current route table.
+func (rt *Router) build() *gorilla.Router {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+
+	return rt.buildLocked()
+}
+
+// buildLocked is build for a caller which already holds the lock.
+func (rt *Router) buildLocked() *gorilla.Router {
+	if rt.router != nil {
+		return rt.router
+	}
+
+	router := gorilla.NewRouter()
+	negotiate := handler.NegotiateErrorFormatWith(rt.options.errorFormat)
+	router.NotFoundHandler = negotiate(http.HandlerFunc(notFound))
+	router.MethodNotAllowedHandler = negotiate(http.HandlerFunc(methodNotAllowed))
+
+	handlers := make([]http.HandlerFunc, len(rt.routes))
+	for i, route := range rt.routes {
+		handlers[i] = rt.options.createHandler(route.Handler)
+
+		r := router.Path(handler.MuxTemplate(route.Path)).HandlerFunc(handlers[i])
+		if route.Method != "" {
+			r.Methods(route.Method)
+		}
+	}
+
+	rt.router = router
+	rt.handlers = handlers
+
+	return router
+}
+
+func notFound(w http.ResponseWriter, req *http.Request) {
+	handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.NotFound("No route matches the request"))
+}
+
+func methodNotAllowed(w http.ResponseWriter, req *http.Request) {
+	handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.MethodNotAllowed("The method is not allowed for the requested route"))
+}
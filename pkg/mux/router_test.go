@@ -0,0 +1,169 @@
+package mux
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	gorilla "github.com/gorilla/mux"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/aws"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/handler"
+	"github.com/itsoneiota/lambda-handlers/v2/pkg/serviceerror"
+	"github.com/stretchr/testify/suite"
+)
+
+type RouterSuite struct {
+	suite.Suite
+	router *Router
+}
+
+func (s *RouterSuite) SetupTest() {
+	s.router = NewRouter()
+	s.router.Use(func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("X-Middleware", "true")
+			next.ServeHTTP(w, r)
+		})
+	})
+	s.router.Get("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("get " + gorilla.Vars(r)["id"]))
+	})
+	s.router.Put("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("put " + gorilla.Vars(r)["id"]))
+	})
+	s.router.Handle("", "/files/{proxy+}", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("file " + gorilla.Vars(r)["proxy"]))
+	})
+}
+
+func (s *RouterSuite) TestServeHTTP() {
+	tests := []struct {
+		method string
+		path   string
+		status int
+		body   string
+	}{
+		{http.MethodGet, "/products/ABC123", http.StatusOK, "get ABC123"},
+		{http.MethodPut, "/products/ABC123", http.StatusOK, "put ABC123"},
+		{http.MethodDelete, "/files/a/b.txt", http.StatusOK, "file a/b.txt"},
+		{http.MethodDelete, "/products/ABC123", http.StatusMethodNotAllowed, `{"error":{"id":"METHOD_NOT_ALLOWED","code":"METHOD_NOT_ALLOWED","message":"The method is not allowed for the requested route"}}`},
+		{http.MethodGet, "/missing", http.StatusNotFound, `{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"No route matches the request"}}`},
+	}
+
+	for _, test := range tests {
+		w := httptest.NewRecorder()
+		s.router.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+		s.Equal(test.status, w.Code, test.path)
+		s.Equal(test.body, w.Body.String(), test.path)
+	}
+}
+
+func (s *RouterSuite) TestLambdaDispatchByResource() {
+	callback := func(event *events.APIGatewayProxyRequest) *events.APIGatewayProxyResponse {
+		res, err := aws.Invoke(context.Background(), s.router.ServeHTTP, event)
+		s.NoError(err)
+
+		return res
+	}
+
+	// The path includes a base path mapping which gorilla would not match, the resource template is used instead.
+	res := callback(&events.APIGatewayProxyRequest{
+		Resource:       "/products/{id}",
+		Path:           "/v1/products/ABC123",
+		HTTPMethod:     http.MethodPut,
+		PathParameters: map[string]string{"id": "ABC123"},
+		RequestContext: events.APIGatewayProxyRequestContext{
+			ResourcePath: "/products/{id}",
+			HTTPMethod:   http.MethodPut,
+		},
+	})
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("put ABC123", res.Body)
+	s.Equal("true", res.Headers["X-Middleware"])
+
+	// The resource is registered for other methods, so the request is refused as it would be locally.
+	res = callback(&events.APIGatewayProxyRequest{
+		Resource:       "/products/{id}",
+		Path:           "/v1/products/ABC123",
+		HTTPMethod:     http.MethodPost,
+		PathParameters: map[string]string{"id": "ABC123"},
+		RequestContext: events.APIGatewayProxyRequestContext{
+			ResourcePath: "/products/{id}",
+			HTTPMethod:   http.MethodPost,
+		},
+	})
+	s.Equal(http.StatusMethodNotAllowed, res.StatusCode)
+	s.Equal(`{"error":{"id":"METHOD_NOT_ALLOWED","code":"METHOD_NOT_ALLOWED","message":"The method is not allowed for the requested route"}}`, res.Body)
+
+	res = callback(&events.APIGatewayProxyRequest{
+		Path:       "/products/XYZ789",
+		HTTPMethod: http.MethodGet,
+	})
+	s.Equal(http.StatusOK, res.StatusCode)
+	s.Equal("get XYZ789", res.Body)
+}
+
+func (s *RouterSuite) TestWithErrorFormat() {
+	router := NewRouter(WithErrorFormat(handler.ProblemFormat))
+	router.Get("/products/{id}", func(w http.ResponseWriter, r *http.Request) {
+		handler.NewResponseHandler().BuildErrorResponse(w, serviceerror.NotFound("Product not found"))
+	})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/products/ABC123", nil))
+	s.Equal(serviceerror.ProblemContentType, w.Header().Get("Content-Type"))
+	s.JSONEq(`{"type":"about:blank","title":"Not Found","status":404,"detail":"Product not found","instance":"/products/ABC123","code":"NOT_FOUND"}`, w.Body.String())
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/missing", nil))
+	s.Equal(http.StatusNotFound, w.Code)
+	s.Equal(serviceerror.ProblemContentType, w.Header().Get("Content-Type"))
+}
+
+func (s *RouterSuite) TestConcurrentHandle() {
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			s.router.Get("/products/{id}/reviews", func(w http.ResponseWriter, r *http.Request) {})
+		}()
+		go func() {
+			defer wg.Done()
+			s.router.HandlerFor(http.MethodGet, "/products/{id}/reviews")
+		}()
+	}
+
+	wg.Wait()
+
+	_, ok := s.router.HandlerFor(http.MethodGet, "/products/{id}/reviews")
+	s.True(ok)
+}
+
+func (s *RouterSuite) TestHandlerFor() {
+	h, ok := s.router.HandlerFor(http.MethodGet, "/products/{id}")
+	s.True(ok)
+
+	w := httptest.NewRecorder()
+	h(w, gorilla.SetURLVars(httptest.NewRequest(http.MethodGet, "/products/ABC123", nil), map[string]string{"id": "ABC123"}))
+	s.Equal("get ABC123", w.Body.String())
+	s.Equal("true", w.Header().Get("X-Middleware"))
+
+	_, ok = s.router.HandlerFor(http.MethodPost, "/products/{id}")
+	s.False(ok)
+
+	s.Len(s.router.Routes(), 3)
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRouterSuite(t *testing.T) {
+	suite.Run(t, new(RouterSuite))
+}
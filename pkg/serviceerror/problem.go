@@ -0,0 +1,39 @@
+package serviceerror
+
+import "net/http"
+
+// ProblemContentType is the media type of an RFC 7807 problem details response
+const ProblemContentType = "application/problem+json"
+
+// Problem is an RFC 7807 problem details representation of a service error.
+// The error code and any field errors are carried as the code and fields extension members.
+type Problem struct {
+	Type     string       `json:"type"`
+	Title    string       `json:"title"`
+	Status   int          `json:"status"`
+	Detail   string       `json:"detail,omitempty"`
+	Instance string       `json:"instance,omitempty"`
+	Code     string       `json:"code,omitempty"`
+	Fields   []FieldError `json:"fields,omitempty"`
+}
+
+// NewProblem creates a problem for the given status. As no problem type URI is defined the type is about:blank,
+// and the title is therefore the status text.
+func NewProblem(status int, code, detail, instance string) *Problem {
+	return &Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   detail,
+		Instance: instance,
+		Code:     code,
+	}
+}
+
+// Problem returns the service error as an RFC 7807 problem, instance identifying the occurrence (e.g. the request path)
+func (se *ServiceError) Problem(instance string) *Problem {
+	p := NewProblem(se.StatusCode(), se.Code(), se.Error(), instance)
+	p.Fields = se.Err.Fields
+
+	return p
+}
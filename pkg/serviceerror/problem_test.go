@@ -0,0 +1,49 @@
+package serviceerror
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type ProblemSuite struct {
+	suite.Suite
+}
+
+func (s *ProblemSuite) TestProblem() {
+	p := NotFound("Product ABC123 not found").Problem("/products/ABC123")
+
+	s.Equal(&Problem{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "Product ABC123 not found",
+		Instance: "/products/ABC123",
+		Code:     CodeNotFound,
+	}, p)
+}
+
+func (s *ProblemSuite) TestProblemJSON() {
+	p := BadRequestWithFields("Validation failed", []FieldError{
+		{Path: "name", Rule: "required", Message: "name is required"},
+	}).Problem("")
+
+	b, err := json.Marshal(p)
+	s.NoError(err)
+	s.JSONEq(`{
+		"type":"about:blank",
+		"title":"Bad Request",
+		"status":400,
+		"detail":"Validation failed",
+		"code":"BAD_REQUEST",
+		"fields":[{"path":"name","rule":"required","message":"name is required"}]
+	}`, string(b))
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestProblemSuite(t *testing.T) {
+	suite.Run(t, new(ProblemSuite))
+}
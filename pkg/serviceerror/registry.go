@@ -0,0 +1,151 @@
+package serviceerror
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// registry maps error codes to their HTTP status and default message, it is safe for concurrent use.
+// Codes added with Register are held by the registry, any other code is looked up in the built in map,
+// StatusCodes, so codes added to it directly are still honoured.
+type registry struct {
+	mu       sync.RWMutex
+	builtIn  map[string]int
+	statuses map[string]int
+	messages map[string]string
+	// codes holds the first code registered for each status.
+	codes map[int]string
+}
+
+var codes = newRegistry(StatusCodes)
+
+func newRegistry(builtIn map[string]int) *registry {
+	return &registry{
+		builtIn:  builtIn,
+		statuses: map[string]int{},
+		messages: map[string]string{},
+		codes:    map[int]string{},
+	}
+}
+
+func (r *registry) register(code string, status int, defaultMessage string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if previous, ok := r.statuses[code]; ok && previous != status && r.codes[previous] == code {
+		delete(r.codes, previous)
+	}
+
+	r.statuses[code] = status
+	r.messages[code] = defaultMessage
+
+	if _, ok := r.codes[status]; !ok {
+		r.codes[status] = code
+	}
+}
+
+func (r *registry) statusCodeFor(code string) (int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return r.status(code)
+}
+
+// status returns the status of code, the caller must hold the lock.
+func (r *registry) status(code string) (int, bool) {
+	if status, ok := r.statuses[code]; ok {
+		return status, true
+	}
+
+	status, ok := r.builtIn[code]
+	return status, ok
+}
+
+func (r *registry) defaultMessageFor(code string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if message, ok := r.messages[code]; ok {
+		return message, true
+	}
+
+	if _, ok := r.builtIn[code]; !ok {
+		return "", false
+	}
+
+	message, ok := defaultErrorMessages[code]
+	return message, ok
+}
+
+// codeFor returns the code for status, preferring the built in codes, then those added with Register,
+// then any added to the built in map directly.
+func (r *registry) codeFor(status int) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	var builtIn, added []string
+	for code := range r.builtIn {
+		if s, _ := r.status(code); s != status {
+			continue
+		}
+
+		if _, ok := defaultErrorMessages[code]; ok {
+			builtIn = append(builtIn, code)
+		} else {
+			added = append(added, code)
+		}
+	}
+
+	if len(builtIn) > 0 {
+		sort.Strings(builtIn)
+		return builtIn[0], true
+	}
+
+	if code, ok := r.codes[status]; ok {
+		return code, true
+	}
+
+	if len(added) > 0 {
+		sort.Strings(added)
+		return added[0], true
+	}
+
+	return "", false
+}
+
+// Register adds a custom error code, or replaces the status and default message of an existing one,
+// and returns a helper for creating service errors with the code. It panics if code is empty or status
+// is not a valid HTTP status, so is best called when initialising a package:
+//
+//	var InsufficientFunds = serviceerror.Register("INSUFFICIENT_FUNDS", http.StatusPaymentRequired, "Insufficient Funds")
+func Register(code string, status int, defaultMessage string) func(message string) *ServiceError {
+	if code == "" {
+		panic("serviceerror: Register called with an empty code")
+	}
+
+	if status < 100 || status > 599 {
+		panic(fmt.Sprintf("serviceerror: Register called with invalid status %d for code %s", status, code))
+	}
+
+	codes.register(code, status, defaultMessage)
+
+	return func(message string) *ServiceError {
+		return NewServiceError(code, code, message)
+	}
+}
+
+// StatusCodeFor returns the HTTP status registered for code
+func StatusCodeFor(code string) (int, bool) {
+	return codes.statusCodeFor(code)
+}
+
+// DefaultMessageFor returns the default message registered for code
+func DefaultMessageFor(code string) (string, bool) {
+	return codes.defaultMessageFor(code)
+}
+
+// CodeFor returns the code registered for an HTTP status, built in codes taking precedence over custom ones
+func CodeFor(status int) (string, bool) {
+	return codes.codeFor(status)
+}
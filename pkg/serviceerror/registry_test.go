@@ -0,0 +1,114 @@
+package serviceerror
+
+import (
+	"fmt"
+	"maps"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type RegistrySuite struct {
+	suite.Suite
+	builtIn map[string]int
+	saved   *registry
+}
+
+// SetupTest replaces the package registry with a fresh one, so codes registered by a test don't leak into others.
+func (s *RegistrySuite) SetupTest() {
+	s.builtIn = maps.Clone(StatusCodes)
+	s.saved = codes
+	codes = newRegistry(s.builtIn)
+}
+
+func (s *RegistrySuite) TearDownTest() {
+	codes = s.saved
+}
+
+func (s *RegistrySuite) TestBuiltInCodes() {
+	for code, status := range StatusCodes {
+		registered, ok := StatusCodeFor(code)
+		s.True(ok, code)
+		s.Equal(status, registered, code)
+
+		message, ok := DefaultMessageFor(code)
+		s.True(ok, code)
+		s.Equal(defaultErrorMessages[code], message, code)
+
+		byStatus, ok := CodeFor(status)
+		s.True(ok, code)
+		s.Equal(code, byStatus, code)
+	}
+
+	_, ok := StatusCodeFor("NOT_REGISTERED")
+	s.False(ok)
+}
+
+func (s *RegistrySuite) TestRegister() {
+	insufficientFunds := Register("INSUFFICIENT_FUNDS", http.StatusPaymentRequired, "Insufficient Funds")
+
+	e := insufficientFunds("Balance too low")
+	s.Equal("INSUFFICIENT_FUNDS", e.Code())
+	s.Equal("Balance too low", e.Error())
+	s.Equal(http.StatusPaymentRequired, e.StatusCode())
+
+	s.Equal("INSUFFICIENT_FUNDS", GetServiceErrorCode(http.StatusPaymentRequired))
+	message, status := GetDefaultErrorMessage(http.StatusPaymentRequired)
+	s.Equal("Insufficient Funds", message)
+	s.Equal(http.StatusPaymentRequired, status)
+
+	Register("INSUFFICIENT_FUNDS", http.StatusUnprocessableEntity, "Insufficient Funds")
+	s.Equal(http.StatusUnprocessableEntity, e.StatusCode())
+	s.Equal(CodeInternalServerError, GetServiceErrorCode(http.StatusPaymentRequired))
+}
+
+func (s *RegistrySuite) TestBuiltInCodeWinsStatus() {
+	Register("USER_NOT_FOUND", http.StatusNotFound, "User Not Found")
+
+	s.Equal(http.StatusNotFound, NewServiceError("", "USER_NOT_FOUND", "").StatusCode())
+	s.Equal(CodeNotFound, GetServiceErrorCode(http.StatusNotFound))
+}
+
+func (s *RegistrySuite) TestStatusCodesAddedDirectly() {
+	s.builtIn["LEGACY_TEAPOT"] = http.StatusTeapot
+	s.builtIn[CodeGone] = http.StatusNotFound
+
+	s.Equal(http.StatusTeapot, NewServiceError("", "LEGACY_TEAPOT", "").StatusCode())
+	s.Equal("LEGACY_TEAPOT", GetServiceErrorCode(http.StatusTeapot))
+	s.Equal(http.StatusNotFound, Gone("").StatusCode())
+	s.Equal(CodeGone, GetServiceErrorCode(http.StatusNotFound))
+
+	Register("LEGACY_TEAPOT", http.StatusPaymentRequired, "Payment Required")
+	s.Equal(http.StatusPaymentRequired, NewServiceError("", "LEGACY_TEAPOT", "").StatusCode())
+}
+
+func (s *RegistrySuite) TestRegisterInvalid() {
+	s.Panics(func() { Register("", http.StatusTeapot, "") })
+	s.Panics(func() { Register("TEAPOT", 42, "") })
+}
+
+func (s *RegistrySuite) TestConcurrentUse() {
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			code := fmt.Sprintf("CONCURRENT_%d", i)
+			helper := Register(code, http.StatusTeapot, "Concurrent")
+			s.Equal(http.StatusTeapot, helper("message").StatusCode())
+			s.Equal(http.StatusNotFound, NotFound("message").StatusCode())
+			GetServiceErrorCode(http.StatusTeapot)
+		}(i)
+	}
+
+	wg.Wait()
+}
+
+// In order for 'go test' to run this suite, we need to create
+// a normal test function and pass our suite to suite.Run
+func TestRegistrySuite(t *testing.T) {
+	suite.Run(t, new(RegistrySuite))
+}
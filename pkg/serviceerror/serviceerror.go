@@ -1,65 +1,101 @@
 package serviceerror
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
 )
 
 // Error codes
 const (
-	CodeUnknown             = "UNKNOWN_ERROR"
-	CodeInternalServerError = "INTERNAL_SERVER_ERROR"
-	CodeNotImplemented      = "NOT_IMPLEMENTED"
-	CodeUnprocessableEntity = "UNPROCESSABLE_ENTITY"
-	CodeConflict            = "CONFLICT"
-	CodeRequestTimeout      = "REQUEST_TIMEOUT"
-	CodeNotFound            = "NOT_FOUND"
-	CodeForbidden           = "FORBIDDEN"
-	CodeUnauthorized        = "UNAUTHORIZED"
-	CodeBadRequest          = "BAD_REQUEST"
-	CodeFound               = "FOUND"
-	CodeMovedPermanently    = "MOVED_PERMANENTLY"
+	CodeUnknown              = "UNKNOWN_ERROR"
+	CodeInternalServerError  = "INTERNAL_SERVER_ERROR"
+	CodeNotImplemented       = "NOT_IMPLEMENTED"
+	CodeUnprocessableEntity  = "UNPROCESSABLE_ENTITY"
+	CodeConflict             = "CONFLICT"
+	CodeRequestTimeout       = "REQUEST_TIMEOUT"
+	CodeGatewayTimeout       = "GATEWAY_TIMEOUT"
+	CodeServiceUnavailable   = "SERVICE_UNAVAILABLE"
+	CodeTooManyRequests      = "TOO_MANY_REQUESTS"
+	CodeNotFound             = "NOT_FOUND"
+	CodeGone                 = "GONE"
+	CodeMethodNotAllowed     = "METHOD_NOT_ALLOWED"
+	CodePayloadTooLarge      = "PAYLOAD_TOO_LARGE"
+	CodeUnsupportedMediaType = "UNSUPPORTED_MEDIA_TYPE"
+	CodeForbidden            = "FORBIDDEN"
+	CodeUnauthorized         = "UNAUTHORIZED"
+	CodeBadRequest           = "BAD_REQUEST"
+	CodeFound                = "FOUND"
+	CodeMovedPermanently     = "MOVED_PERMANENTLY"
 )
 
-// StatusCodes mapped to the error codes
+// StatusCodes mapped to the built in error codes.
+//
+// Deprecated: use Register to add codes and StatusCodeFor to look them up. Codes added to the map are still
+// honoured for codes not passed to Register, but writing to it is not safe once errors are being handled concurrently.
 var StatusCodes = map[string]int{
-	CodeInternalServerError: http.StatusInternalServerError,
-	CodeNotImplemented:      http.StatusNotImplemented,
-	CodeUnprocessableEntity: http.StatusUnprocessableEntity,
-	CodeConflict:            http.StatusConflict,
-	CodeRequestTimeout:      http.StatusRequestTimeout,
-	CodeNotFound:            http.StatusNotFound,
-	CodeForbidden:           http.StatusForbidden,
-	CodeUnauthorized:        http.StatusUnauthorized,
-	CodeBadRequest:          http.StatusBadRequest,
-	CodeFound:               http.StatusFound,
-	CodeMovedPermanently:    http.StatusMovedPermanently,
+	CodeInternalServerError:  http.StatusInternalServerError,
+	CodeNotImplemented:       http.StatusNotImplemented,
+	CodeUnprocessableEntity:  http.StatusUnprocessableEntity,
+	CodeConflict:             http.StatusConflict,
+	CodeRequestTimeout:       http.StatusRequestTimeout,
+	CodeGatewayTimeout:       http.StatusGatewayTimeout,
+	CodeServiceUnavailable:   http.StatusServiceUnavailable,
+	CodeTooManyRequests:      http.StatusTooManyRequests,
+	CodeNotFound:             http.StatusNotFound,
+	CodeGone:                 http.StatusGone,
+	CodeMethodNotAllowed:     http.StatusMethodNotAllowed,
+	CodePayloadTooLarge:      http.StatusRequestEntityTooLarge,
+	CodeUnsupportedMediaType: http.StatusUnsupportedMediaType,
+	CodeForbidden:            http.StatusForbidden,
+	CodeUnauthorized:         http.StatusUnauthorized,
+	CodeBadRequest:           http.StatusBadRequest,
+	CodeFound:                http.StatusFound,
+	CodeMovedPermanently:     http.StatusMovedPermanently,
 }
 
 // defaultErrorMessages are default error messages if we are unable to get a message from the client error.
 var defaultErrorMessages = map[string]string{
-	CodeInternalServerError: "Internal Service Error",
-	CodeNotImplemented:      "Not Implemented",
-	CodeUnprocessableEntity: "Unprocessable Entity",
-	CodeConflict:            "Conflict",
-	CodeRequestTimeout:      "Request Timeout",
-	CodeNotFound:            "Not Found",
-	CodeForbidden:           "Forbidden",
-	CodeUnauthorized:        "Unauthorized",
-	CodeBadRequest:          "Bad Request",
-	CodeFound:               "Found",
-	CodeMovedPermanently:    "Moved Permanently",
+	CodeInternalServerError:  "Internal Service Error",
+	CodeNotImplemented:       "Not Implemented",
+	CodeUnprocessableEntity:  "Unprocessable Entity",
+	CodeConflict:             "Conflict",
+	CodeRequestTimeout:       "Request Timeout",
+	CodeGatewayTimeout:       "Gateway Timeout",
+	CodeServiceUnavailable:   "Service Unavailable",
+	CodeTooManyRequests:      "Too Many Requests",
+	CodeNotFound:             "Not Found",
+	CodeGone:                 "Gone",
+	CodeMethodNotAllowed:     "Method Not Allowed",
+	CodePayloadTooLarge:      "Payload Too Large",
+	CodeUnsupportedMediaType: "Unsupported Media Type",
+	CodeForbidden:            "Forbidden",
+	CodeUnauthorized:         "Unauthorized",
+	CodeBadRequest:           "Bad Request",
+	CodeFound:                "Found",
+	CodeMovedPermanently:     "Moved Permanently",
 }
 
 // ServiceError - represents the service error
 type ServiceError struct {
-	Err Error `json:"error"`
+	Err   Error `json:"error"`
+	cause error
 }
 
 // Error holds the error contents of the service error
 type Error struct {
-	ID      string `json:"id"`
-	Code    string `json:"code"`
+	ID      string       `json:"id"`
+	Code    string       `json:"code"`
+	Message string       `json:"message"`
+	Fields  []FieldError `json:"fields,omitempty"`
+}
+
+// FieldError describes why a single field of a request is invalid
+type FieldError struct {
+	// Path locates the field in the request, e.g. address.postcode or items[0].sku
+	Path string `json:"path"`
+	// Rule is the validation rule which failed, e.g. required or max
+	Rule    string `json:"rule"`
 	Message string `json:"message"`
 }
 
@@ -73,10 +109,30 @@ func (se *ServiceError) Code() string {
 	return se.Err.Code
 }
 
-// StatusCode returns the errors StatusCode
+// Unwrap returns the error which caused the service error, if any
+func (se *ServiceError) Unwrap() error {
+	return se.cause
+}
+
+// Is reports whether target is a service error with the same code, so errors.Is(err, NotFound("")) matches any not found error
+func (se *ServiceError) Is(target error) bool {
+	t, ok := target.(*ServiceError)
+	return ok && t.Code() == se.Code()
+}
+
+// WithCause returns a copy of the service error caused by err. The cause is never shown to the client
+// but remains reachable with errors.Is and errors.As.
+func (se *ServiceError) WithCause(err error) *ServiceError {
+	c := *se
+	c.cause = err
+
+	return &c
+}
+
+// StatusCode returns the errors StatusCode, 500 if the code has not been registered
 func (se *ServiceError) StatusCode() int {
 	respCode := http.StatusInternalServerError
-	if val, ok := StatusCodes[se.Err.Code]; ok {
+	if val, ok := StatusCodeFor(se.Err.Code); ok {
 		respCode = val
 	}
 	return respCode
@@ -89,7 +145,7 @@ func NewServiceError(id, code, message string) *ServiceError {
 	}
 
 	return &ServiceError{
-		Error{
+		Err: Error{
 			ID:      id,
 			Code:    code,
 			Message: message,
@@ -97,17 +153,20 @@ func NewServiceError(id, code, message string) *ServiceError {
 	}
 }
 
-// NewFromErr returns a new service error built from an existing error
+// NewFromErr returns a new service error built from an existing error, which becomes its cause.
+// The code is taken from the first service error in err's chain.
 func NewFromErr(err error, message string) *ServiceError {
 	code := CodeInternalServerError
-	if e, ok := err.(*ServiceError); ok {
+	var e *ServiceError
+	if errors.As(err, &e) {
 		code = e.Code()
 	}
 	return &ServiceError{
-		Error{
+		Err: Error{
 			Code:    code,
 			Message: fmt.Sprintf("%s: %s", message, err.Error()),
 		},
+		cause: err,
 	}
 }
 
@@ -136,11 +195,46 @@ func RequestTimeout(message string) *ServiceError {
 	return NewServiceError(CodeRequestTimeout, CodeRequestTimeout, message)
 }
 
+// GatewayTimeout is a helper method for creating a service error with an 'GatewayTimeout' code
+func GatewayTimeout(message string) *ServiceError {
+	return NewServiceError(CodeGatewayTimeout, CodeGatewayTimeout, message)
+}
+
+// ServiceUnavailable is a helper method for creating a service error with an 'ServiceUnavailable' code
+func ServiceUnavailable(message string) *ServiceError {
+	return NewServiceError(CodeServiceUnavailable, CodeServiceUnavailable, message)
+}
+
+// TooManyRequests is a helper method for creating a service error with an 'TooManyRequests' code
+func TooManyRequests(message string) *ServiceError {
+	return NewServiceError(CodeTooManyRequests, CodeTooManyRequests, message)
+}
+
 // NotFound is a helper method for creating a service error with an 'NotFound' code
 func NotFound(message string) *ServiceError {
 	return NewServiceError(CodeNotFound, CodeNotFound, message)
 }
 
+// Gone is a helper method for creating a service error with an 'Gone' code
+func Gone(message string) *ServiceError {
+	return NewServiceError(CodeGone, CodeGone, message)
+}
+
+// MethodNotAllowed is a helper method for creating a service error with an 'MethodNotAllowed' code
+func MethodNotAllowed(message string) *ServiceError {
+	return NewServiceError(CodeMethodNotAllowed, CodeMethodNotAllowed, message)
+}
+
+// PayloadTooLarge is a helper method for creating a service error with an 'PayloadTooLarge' code
+func PayloadTooLarge(message string) *ServiceError {
+	return NewServiceError(CodePayloadTooLarge, CodePayloadTooLarge, message)
+}
+
+// UnsupportedMediaType is a helper method for creating a service error with an 'UnsupportedMediaType' code
+func UnsupportedMediaType(message string) *ServiceError {
+	return NewServiceError(CodeUnsupportedMediaType, CodeUnsupportedMediaType, message)
+}
+
 // Forbidden is a helper method for creating a service error with an 'Forbidden' code
 func Forbidden(message string) *ServiceError {
 	return NewServiceError(CodeForbidden, CodeForbidden, message)
@@ -156,6 +250,14 @@ func BadRequest(message string) *ServiceError {
 	return NewServiceError(CodeBadRequest, CodeBadRequest, message)
 }
 
+// BadRequestWithFields is a helper method for creating a service error with a 'BadRequest' code listing the invalid fields
+func BadRequestWithFields(message string, fields []FieldError) *ServiceError {
+	se := BadRequest(message)
+	se.Err.Fields = fields
+
+	return se
+}
+
 // Found is a helper method for creating a service error with an 'Found' code
 func Found(message string) *ServiceError {
 	return NewServiceError(CodeFound, CodeFound, message)
@@ -168,32 +270,22 @@ func MovedPermanently(message string) *ServiceError {
 
 // GetDefaultErrorMessage get the default error message
 func GetDefaultErrorMessage(statusCode int) (string, int) {
-	var message string
-	var status int
-	for k, v := range StatusCodes {
-		if v == statusCode {
-			message = defaultErrorMessages[k]
-			status = StatusCodes[k]
+	if code, ok := CodeFor(statusCode); ok {
+		if message, ok := DefaultMessageFor(code); ok {
+			return message, statusCode
 		}
 	}
 
-	if message == "" {
-		message = defaultErrorMessages[CodeInternalServerError]
-		status = StatusCodes[CodeInternalServerError]
-	}
+	message, _ := DefaultMessageFor(CodeInternalServerError)
 
-	return message, status
+	return message, http.StatusInternalServerError
 }
 
 // GetServiceErrorCode gets the service error code based on a given http status.
 func GetServiceErrorCode(statusCode int) string {
-	result := CodeInternalServerError
-	for k, v := range StatusCodes {
-		if v == statusCode {
-			result = k
-			break
-		}
+	if code, ok := CodeFor(statusCode); ok {
+		return code
 	}
 
-	return result
+	return CodeInternalServerError
 }
@@ -1,7 +1,9 @@
 package serviceerror
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"net/http"
 	"testing"
 
@@ -40,7 +42,15 @@ func (s *ServiceErrorSuite) TestGetStatusCode() {
 		{CodeUnauthorized, http.StatusUnauthorized},
 		{CodeForbidden, http.StatusForbidden},
 		{CodeRequestTimeout, http.StatusRequestTimeout},
+		{CodeGatewayTimeout, http.StatusGatewayTimeout},
 		{CodeConflict, http.StatusConflict},
+		{CodeServiceUnavailable, http.StatusServiceUnavailable},
+		{CodeTooManyRequests, http.StatusTooManyRequests},
+		{CodeGone, http.StatusGone},
+		{CodeMethodNotAllowed, http.StatusMethodNotAllowed},
+		{CodePayloadTooLarge, http.StatusRequestEntityTooLarge},
+		{CodeUnsupportedMediaType, http.StatusUnsupportedMediaType},
+		{CodeUnknown, http.StatusInternalServerError},
 	}
 	err := NewServiceError("", "", "")
 	for _, test := range tests {
@@ -74,7 +84,14 @@ func (s *ServiceErrorSuite) TestConvenienceMethods() {
 		{UnprocessableEntity, CodeUnprocessableEntity},
 		{Conflict, CodeConflict},
 		{RequestTimeout, CodeRequestTimeout},
+		{GatewayTimeout, CodeGatewayTimeout},
+		{ServiceUnavailable, CodeServiceUnavailable},
+		{TooManyRequests, CodeTooManyRequests},
 		{NotFound, CodeNotFound},
+		{Gone, CodeGone},
+		{MethodNotAllowed, CodeMethodNotAllowed},
+		{PayloadTooLarge, CodePayloadTooLarge},
+		{UnsupportedMediaType, CodeUnsupportedMediaType},
 		{Forbidden, CodeForbidden},
 		{Unauthorized, CodeUnauthorized},
 		{BadRequest, CodeBadRequest},
@@ -89,6 +106,58 @@ func (s *ServiceErrorSuite) TestConvenienceMethods() {
 	}
 }
 
+func (s *ServiceErrorSuite) TestBadRequestWithFields() {
+	e := BadRequestWithFields("Validation failed", []FieldError{
+		{Path: "address.postcode", Rule: "required", Message: "address.postcode is required"},
+	})
+	s.Equal(CodeBadRequest, e.Code())
+	s.Equal(http.StatusBadRequest, e.StatusCode())
+
+	b, err := json.Marshal(e)
+	s.NoError(err)
+	s.JSONEq(`{"error":{"id":"BAD_REQUEST","code":"BAD_REQUEST","message":"Validation failed","fields":[{"path":"address.postcode","rule":"required","message":"address.postcode is required"}]}}`, string(b))
+
+	b, err = json.Marshal(BadRequest("borken"))
+	s.NoError(err)
+	s.NotContains(string(b), "fields")
+}
+
+func (s *ServiceErrorSuite) TestIsMatchesByCode() {
+	err := fmt.Errorf("loading user: %w", NotFound("User not found"))
+
+	s.True(errors.Is(err, NotFound("")))
+	s.False(errors.Is(err, Conflict("")))
+	s.False(errors.Is(err, errors.New("User not found")))
+
+	var se *ServiceError
+	s.True(errors.As(err, &se))
+	s.Equal("User not found", se.Error())
+}
+
+func (s *ServiceErrorSuite) TestWithCause() {
+	cause := errors.New("connection refused")
+	notFound := NotFound("User not found")
+	err := notFound.WithCause(cause)
+
+	s.Equal("User not found", err.Error())
+	s.Equal(cause, errors.Unwrap(err))
+	s.True(errors.Is(err, cause))
+	s.True(errors.Is(err, NotFound("")))
+	s.Nil(notFound.Unwrap())
+
+	b, jsonErr := json.Marshal(err)
+	s.NoError(jsonErr)
+	s.JSONEq(`{"error":{"id":"NOT_FOUND","code":"NOT_FOUND","message":"User not found"}}`, string(b))
+}
+
+func (s *ServiceErrorSuite) TestNewFromWrappedErr() {
+	cause := fmt.Errorf("loading user: %w", NotFound("not found"))
+	err := NewFromErr(cause, "error")
+
+	s.Equal(CodeNotFound, err.Code())
+	s.Equal(cause, errors.Unwrap(err))
+}
+
 func (s *ServiceErrorSuite) TestGetDefaultErrorMessage() {
 	tests := []struct {
 		method       func(string) *ServiceError
@@ -99,7 +168,14 @@ func (s *ServiceErrorSuite) TestGetDefaultErrorMessage() {
 		{UnprocessableEntity, CodeUnprocessableEntity},
 		{Conflict, CodeConflict},
 		{RequestTimeout, CodeRequestTimeout},
+		{GatewayTimeout, CodeGatewayTimeout},
+		{ServiceUnavailable, CodeServiceUnavailable},
+		{TooManyRequests, CodeTooManyRequests},
 		{NotFound, CodeNotFound},
+		{Gone, CodeGone},
+		{MethodNotAllowed, CodeMethodNotAllowed},
+		{PayloadTooLarge, CodePayloadTooLarge},
+		{UnsupportedMediaType, CodeUnsupportedMediaType},
 		{Forbidden, CodeForbidden},
 		{Unauthorized, CodeUnauthorized},
 		{BadRequest, CodeBadRequest},